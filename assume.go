@@ -0,0 +1,7 @@
+//go:build !try_debug
+
+package try
+
+// Assume panics with msg if cond is false, like Assert, but only in builds with the try_debug tag.
+// Otherwise it is a no-op.
+func Assume(cond bool, msg string) {}
@@ -0,0 +1,13 @@
+//go:build try_debug
+
+package try
+
+import "errors"
+
+// Assume panics with msg if cond is false, like Assert, but only in builds with the try_debug tag.
+// Otherwise it is a no-op.
+func Assume(cond bool, msg string) {
+	if !cond {
+		checkErr(errors.New(msg))
+	}
+}
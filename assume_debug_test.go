@@ -0,0 +1,19 @@
+//go:build try_debug
+
+package try_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goldic/try"
+	"github.com/goldic/try/trytest"
+)
+
+func TestAssume(t *testing.T) {
+	trytest.NoPanic(t, func() { try.Assume(true, "unreachable") })
+	err := try.Call(func() { try.Assume(false, "invariant broken") })
+	if err == nil || !strings.HasPrefix(err.Error(), "invariant broken") {
+		t.Fatalf("got %v, want the assumption failure", err)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !try_debug
+
+package try_test
+
+import (
+	"testing"
+
+	"github.com/goldic/try"
+	"github.com/goldic/try/trytest"
+)
+
+func TestAssume(t *testing.T) {
+	trytest.NoPanic(t, func() { try.Assume(false, "compiled out") })
+	if n := testing.AllocsPerRun(100, func() { try.Assume(false, "compiled out") }); n != 0 {
+		t.Fatalf("got %v allocs, want 0", n)
+	}
+}
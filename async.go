@@ -0,0 +1,453 @@
+package try
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Async asynchronously runs several functions and waits for them to complete, returns an error in case of panic.
+func Async(fn ...func()) error {
+	return async(asyncOptions{}, fn)
+}
+
+// AsyncLimit runs several functions like Async, but no more than limit of them at once.
+// A limit less than 1 means no limit.
+func AsyncLimit(limit int, fn ...func()) error {
+	return async(asyncOptions{limit: limit}, fn)
+}
+
+// AsyncOrdered runs several functions like Async, but joins the errors in the order of the functions.
+func AsyncOrdered(fn ...func()) error {
+	return async(asyncOptions{ordered: true}, fn)
+}
+
+// AsyncMaxErrors runs several functions like Async, but joins no more than maxErrors errors.
+// The rest are summarized as "and N more errors".
+func AsyncMaxErrors(maxErrors int, fn ...func()) error {
+	return async(asyncOptions{maxErrors: maxErrors}, fn)
+}
+
+// errNoFunctions is returned by WaitFirst called without functions, as none of them has succeeded.
+var errNoFunctions = errors.New("try: no functions to wait for")
+
+// WaitFirst runs several functions concurrently and returns the first successful result without waiting for the rest.
+// If all functions fail, their errors and recovered panics are annotated with the index and joined in order.
+// Without functions it returns an error.
+func WaitFirst[T any](fn ...func() (T, error)) (T, error) {
+	if len(fn) == 0 {
+		var zero T
+		return zero, errNoFunctions
+	}
+	type result struct {
+		i   int
+		v   T
+		err error
+	}
+	ch := make(chan result, len(fn)) // buffered, so that the losers don't block
+	for i, f := range fn {
+		go func() {
+			r := result{i: i}
+			r.err = CallResult(func() (err error) {
+				r.v, err = f()
+				return
+			})
+			ch <- r
+		}()
+	}
+	errs := make([]error, len(fn))
+	for range fn {
+		r := <-ch
+		if r.err == nil {
+			return r.v, nil
+		}
+		errs[r.i] = indexError(r.i, r.err)
+	}
+	var zero T
+	return zero, joinSlice(errs)
+}
+
+// AsyncResults runs several functions concurrently and returns their errors or recovered panics
+// in the order of the functions, nil for the functions that succeeded.
+func AsyncResults(fn ...func() error) []error {
+	errs := make([]error, len(fn))
+	fns := make([]func(), len(fn))
+	for i, f := range fn {
+		fns[i] = func() {
+			errs[i] = CallResult(f)
+		}
+	}
+	async(asyncOptions{}, fns)
+	return errs
+}
+
+// Task is a named function run by AsyncLabeled.
+type Task struct {
+	Name string
+	Fn   func()
+}
+
+// AsyncLabeled runs the tasks concurrently like Async, but annotates each recovered panic with the name of its task.
+// The errors are joined in the order of the tasks.
+func AsyncLabeled(tasks ...Task) error {
+	errs := make([]error, len(tasks))
+	fns := make([]func(), len(tasks))
+	for i, t := range tasks {
+		fns[i] = func() {
+			if err := Call(t.Fn); err != nil {
+				errs[i] = fmt.Errorf("task '%s': %w", t.Name, err)
+			}
+		}
+	}
+	async(asyncOptions{}, fns)
+	return joinSlice(slices.DeleteFunc(errs, func(err error) bool { return err == nil }))
+}
+
+// BatchAsync runs the functions in consecutive batches of batchSize, each batch concurrently like Async,
+// and waits for a batch to complete before starting the next one. The errors of all batches are joined.
+// A batchSize less than 1 runs all functions in one batch.
+func BatchAsync(batchSize int, fn ...func()) error {
+	if batchSize < 1 {
+		batchSize = len(fn)
+	}
+	var errs []error
+	for i := 0; i < len(fn); i += batchSize {
+		if err := Async(fn[i:min(i+batchSize, len(fn))]...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return joinSlice(errs)
+}
+
+// AsyncConfig configures AsyncWith.
+type AsyncConfig struct {
+	// FailFast makes AsyncWith return the first error without waiting for the other functions.
+	// Functions not yet started are skipped, but running functions can't be interrupted
+	// and keep running in the background, so they should watch their own cancellation signal.
+	FailFast bool
+
+	// Limit is the max number of functions running at once, no limit if less than 1.
+	Limit int
+
+	// Ordered joins the errors in the order of the functions. It has no effect with FailFast,
+	// which returns a single error.
+	Ordered bool
+}
+
+// AsyncWith runs several functions like Async, configured by cfg.
+func AsyncWith(cfg AsyncConfig, fn ...func()) error {
+	return async(asyncOptions{limit: cfg.Limit, ordered: cfg.Ordered, failFast: cfg.FailFast}, fn)
+}
+
+// AsyncTimeout runs several functions like Async, but returns ErrTimeout if they don't all complete within d,
+// joined with the errors of the panics which have occurred so far.
+// Functions still running after the timeout are abandoned and keep running.
+func AsyncTimeout(d time.Duration, fn ...func()) error {
+	var mxErr sync.Mutex
+	var errs []error
+	var wg sync.WaitGroup
+	wg.Add(len(fn))
+	for _, f := range fn {
+		go func(fn func()) {
+			defer wg.Done()
+			if err := Call(fn); err != nil {
+				mxErr.Lock()
+				defer mxErr.Unlock()
+				errs = append(errs, err)
+			}
+		}(f)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return joinSlice(errs)
+	case <-timer.C:
+		mxErr.Lock()
+		defer mxErr.Unlock()
+		return joinSlice(append(slices.Clip(errs), ErrTimeout))
+	}
+}
+
+// AsyncContext runs several functions concurrently and waits for them to complete.
+// The context passed to the functions is cancelled on the first error or panic, which is returned.
+func AsyncContext(ctx context.Context, fn ...func(context.Context) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	wg.Add(len(fn))
+	var once sync.Once
+	var firstErr error
+	for _, f := range fn {
+		go func(fn func(context.Context) error) {
+			defer wg.Done()
+			if err := CallResult(func() error { return fn(ctx) }); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(f)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// ParallelMap returns the results of fn applied to each element of in, running no more than limit calls at once.
+// The results are in the order of in. Errors and recovered panics are annotated with the index and joined in order.
+// A limit less than 1 means no limit. See ParallelMapFirstErr to return the first error only.
+func ParallelMap[T, R any](limit int, in []T, fn func(T) (R, error)) ([]R, error) {
+	out := make([]R, len(in))
+	errs := make([]error, len(in))
+	fns := make([]func(), len(in))
+	for i, v := range in {
+		fns[i] = func() {
+			var r R
+			if errs[i] = CallResult(func() (err error) {
+				r, err = fn(v)
+				return
+			}); errs[i] != nil {
+				errs[i] = indexError(i, errs[i])
+				return
+			}
+			out[i] = r
+		}
+	}
+	async(asyncOptions{limit: limit}, fns)
+	return out, joinSlice(slices.DeleteFunc(errs, func(err error) bool { return err == nil }))
+}
+
+// ParallelMapFirstErr returns the results of fn applied to each element of in like ParallelMap,
+// but returns only the first error, annotated with the index, and skips the elements not yet started after it.
+// The calls already running are waited for. The results of failed and skipped elements are zero values.
+func ParallelMapFirstErr[T, R any](limit int, in []T, fn func(T) (R, error)) ([]R, error) {
+	out := make([]R, len(in))
+	var first firstError
+	fns := make([]func(), len(in))
+	for i, v := range in {
+		fns[i] = func() {
+			if first.failed() {
+				return
+			}
+			var r R
+			if err := CallResult(func() (err error) {
+				r, err = fn(v)
+				return
+			}); err != nil {
+				first.set(indexError(i, err))
+				return
+			}
+			out[i] = r
+		}
+	}
+	async(asyncOptions{limit: limit}, fns)
+	return out, first.err
+}
+
+// firstError keeps the first of concurrently reported errors.
+type firstError struct {
+	once sync.Once
+	done atomic.Bool
+	err  error
+}
+
+func (e *firstError) set(err error) {
+	e.once.Do(func() {
+		e.err = err
+		e.done.Store(true)
+	})
+}
+
+func (e *firstError) failed() bool {
+	return e.done.Load()
+}
+
+// FanOut processes the inputs with fn in a pool of workers goroutines and returns the results of the successful calls.
+// Unlike ParallelMap, the results are in the order of completion, not in the order of inputs.
+// Errors and recovered panics are joined. A number of workers less than 1 means one worker.
+func FanOut[T, R any](workers int, inputs []T, fn func(T) (R, error)) ([]R, error) {
+	return fanOut(workers, inputs, fn, false)
+}
+
+// FanOutFirstErr processes the inputs like FanOut, but returns only the first error
+// and stops handing out the remaining inputs after it. The calls already running are waited for.
+func FanOutFirstErr[T, R any](workers int, inputs []T, fn func(T) (R, error)) ([]R, error) {
+	return fanOut(workers, inputs, fn, true)
+}
+
+func fanOut[T, R any](workers int, inputs []T, fn func(T) (R, error), firstErr bool) ([]R, error) {
+	ch := make(chan T)
+	failed := make(chan struct{}) // closed on the first error if firstErr
+	go func() {
+		defer close(ch)
+		for _, v := range inputs {
+			select {
+			case ch <- v:
+			case <-failed:
+				return
+			}
+		}
+	}()
+	var mx sync.Mutex
+	var out []R
+	var errs []error
+	var wg sync.WaitGroup
+	wg.Add(max(workers, 1))
+	for range max(workers, 1) {
+		go func() {
+			defer wg.Done()
+			for v := range ch {
+				select {
+				case <-failed: // skip inputs received after the first error
+					continue
+				default:
+				}
+				var r R
+				err := CallResult(func() (err error) {
+					r, err = fn(v)
+					return
+				})
+				mx.Lock()
+				switch {
+				case err == nil:
+					out = append(out, r)
+				case !firstErr:
+					errs = append(errs, err)
+				case len(errs) == 0:
+					errs = append(errs, err)
+					close(failed)
+				}
+				mx.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return out, joinSlice(errs)
+}
+
+// WaitAll runs several functions concurrently and returns their results in the order of the functions.
+// The result of a failed function is the zero value. Errors and recovered panics are annotated with the index and joined in order.
+func WaitAll[T any](fn ...func() (T, error)) ([]T, error) {
+	out := make([]T, len(fn))
+	errs := make([]error, len(fn))
+	fns := make([]func(), len(fn))
+	for i, f := range fn {
+		fns[i] = func() {
+			var v T
+			if errs[i] = CallResult(func() (err error) {
+				v, err = f()
+				return
+			}); errs[i] != nil {
+				errs[i] = indexError(i, errs[i])
+				return
+			}
+			out[i] = v
+		}
+	}
+	async(asyncOptions{}, fns)
+	return out, joinSlice(slices.DeleteFunc(errs, func(err error) bool { return err == nil }))
+}
+
+type asyncOptions struct {
+	limit     int  // max number of functions running at once, no limit if < 1
+	ordered   bool // join errors in the order of the functions
+	maxErrors int  // max number of joined errors, no limit if < 1
+	failFast  bool // return the first error without waiting for the rest
+}
+
+func async(opt asyncOptions, fn []func()) error {
+	var sem chan struct{}
+	if opt.limit > 0 {
+		sem = make(chan struct{}, opt.limit)
+	}
+	var failed chan struct{} // closed on the first error in fail-fast mode
+	if opt.failFast {
+		failed = make(chan struct{})
+	}
+	var wg sync.WaitGroup
+	var mxErr sync.Mutex
+	var errs []error
+	ordered := opt.ordered && !opt.failFast // fail-fast mode returns a single error
+	if ordered {
+		errs = make([]error, len(fn))
+	}
+	dropped := 0
+loop:
+	for i, f := range fn {
+		if sem != nil {
+			select {
+			case sem <- struct{}{}:
+			case <-failed:
+				break loop
+			}
+		}
+		if opt.failFast {
+			select {
+			case <-failed: // don't start functions after a failure
+				break loop
+			default:
+			}
+		}
+		wg.Add(1)
+		go func(fn func()) {
+			defer wg.Done()
+			if sem != nil {
+				defer func() { <-sem }()
+			}
+			if err := Call(fn); err != nil {
+				if ordered { // each goroutine owns its own slot
+					errs[i] = err
+					return
+				}
+				mxErr.Lock()
+				defer mxErr.Unlock()
+				if opt.failFast {
+					if len(errs) == 0 {
+						errs = append(errs, err)
+						close(failed)
+					}
+					return
+				}
+				if opt.maxErrors > 0 && len(errs) >= opt.maxErrors {
+					dropped++
+					return
+				}
+				errs = append(errs, err)
+			}
+		}(f)
+	}
+	if opt.failFast {
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-failed:
+		}
+		mxErr.Lock()
+		defer mxErr.Unlock()
+		return joinSlice(slices.Clip(errs))
+	}
+	wg.Wait()
+	if ordered {
+		errs = slices.DeleteFunc(errs, func(err error) bool { return err == nil })
+		if opt.maxErrors > 0 && len(errs) > opt.maxErrors {
+			dropped, errs = len(errs)-opt.maxErrors, errs[:opt.maxErrors]
+		}
+	}
+	if dropped > 0 {
+		errs = append(errs, fmt.Errorf("and %d more errors", dropped))
+	}
+	return joinSlice(errs)
+}
@@ -0,0 +1,413 @@
+package try_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goldic/try"
+)
+
+// concurrency tracks the max number of functions running at once.
+type concurrency struct {
+	running, peak atomic.Int32
+}
+
+// enter marks a function as running for a moment and returns the function marking it as done.
+func (c *concurrency) enter() func() {
+	n := c.running.Add(1)
+	for p := c.peak.Load(); n > p && !c.peak.CompareAndSwap(p, n); p = c.peak.Load() {
+	}
+	time.Sleep(time.Millisecond)
+	return func() { c.running.Add(-1) }
+}
+
+func TestAsyncLimit(t *testing.T) {
+	var c concurrency
+	fns := make([]func(), 20)
+	for i := range fns {
+		fns[i] = func() {
+			defer c.enter()()
+			if i%2 == 0 {
+				try.Check(fmt.Errorf("fn %d", i))
+			}
+		}
+	}
+	err := try.AsyncLimit(3, fns...)
+	if p := c.peak.Load(); p > 3 {
+		t.Fatalf("%d functions at once, want no more than 3", p)
+	}
+	if n := len(try.Errors(err)); n != 10 {
+		t.Fatalf("got %d errors, want 10: %v", n, err)
+	}
+	if err := try.AsyncLimit(0, func() {}, func() {}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestAsyncWith(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	for _, failFast := range []bool{false, true} {
+		for _, limit := range []int{0, 1, 2} {
+			for _, ordered := range []bool{false, true} {
+				cfg := try.AsyncConfig{FailFast: failFast, Limit: limit, Ordered: ordered}
+				t.Run(fmt.Sprintf("%+v", cfg), func(t *testing.T) {
+					if err := try.AsyncWith(cfg, func() {}, func() {}); err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+
+					err := try.AsyncWith(cfg, func() { try.Check(errA) }, func() {}, func() { try.Check(errB) })
+					if err == nil {
+						t.Fatal("expected error")
+					}
+					_ = err.Error() // must not panic
+					for _, e := range try.Errors(err) {
+						if e == nil {
+							t.Fatalf("nil error in %#v", err)
+						}
+					}
+					if failFast {
+						if n := len(try.Errors(err)); n != 1 {
+							t.Fatalf("got %d errors, want 1: %v", n, err)
+						}
+						return
+					}
+					if !errors.Is(err, errA) || !errors.Is(err, errB) {
+						t.Fatalf("missing errors: %v", err)
+					}
+					if ordered && strings.Index(err.Error(), "a") > strings.Index(err.Error(), "b") {
+						t.Fatalf("unordered errors: %v", err)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestAsyncWithFailFastDoesNotWait(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	err := try.AsyncWith(try.AsyncConfig{FailFast: true, Ordered: true},
+		func() { panic("a") },
+		func() { <-release },
+	)
+	if !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestAsyncMaxErrors(t *testing.T) {
+	fns := make([]func(), 10)
+	for i := range fns {
+		fns[i] = func() { try.Check(fmt.Errorf("fn %d", i)) }
+	}
+	err := try.AsyncMaxErrors(3, fns...)
+	errs := try.Errors(err)
+	if len(errs) != 4 || errs[3].Error() != "and 7 more errors" {
+		t.Fatalf("got %d errors, want 3 and a summary: %v", len(errs), err)
+	}
+	if n := len(try.Errors(try.AsyncMaxErrors(0, fns...))); n != 10 {
+		t.Fatalf("got %d errors, want all 10 without a limit", n)
+	}
+	if err := try.AsyncMaxErrors(3, fns[:3]...); strings.Contains(err.Error(), "more errors") {
+		t.Fatalf("unexpected summary: %v", err)
+	}
+}
+
+func TestAsyncTimeout(t *testing.T) {
+	err := try.AsyncTimeout(time.Second, func() {}, func() { panic("boom") })
+	if !errors.Is(err, try.ErrPanic) || errors.Is(err, try.ErrTimeout) {
+		t.Fatalf("got %v, want the panic-error only", err)
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	panicked := make(chan struct{})
+	err = try.AsyncTimeout(20*time.Millisecond,
+		func() { defer close(panicked); try.Check(errTest) },
+		func() { <-panicked; <-release },
+	)
+	if !errors.Is(err, try.ErrTimeout) || !errors.Is(err, errTest) {
+		t.Fatalf("got %v, want the timeout and the earlier error", err)
+	}
+}
+
+func TestWaitAll(t *testing.T) {
+	out, err := try.WaitAll(
+		func() (int, error) { time.Sleep(2 * time.Millisecond); return 1, nil },
+		func() (int, error) { return 2, errTest },
+		func() (int, error) { panic("boom") },
+		func() (int, error) { return 4, nil },
+	)
+	if want := []int{1, 0, 0, 4}; !slices.Equal(out, want) {
+		t.Fatalf("got %v, want %v", out, want)
+	}
+	if msg := err.Error(); !strings.HasPrefix(msg, "index 1: test error") || !strings.Contains(msg, "index 2: boom") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out, err = try.WaitAll(func() (int, error) { return 1, nil })
+	if err != nil || !slices.Equal(out, []int{1}) {
+		t.Fatalf("got %v, %v", out, err)
+	}
+}
+
+func TestBatchAsync(t *testing.T) {
+	var c concurrency
+	var completed atomic.Int32
+	fns := make([]func(), 7)
+	for i := range fns {
+		fns[i] = func() {
+			if n := completed.Load(); int(n) < i/3*3 {
+				t.Errorf("fn %d started with %d functions completed, want the previous batches done", i, n)
+			}
+			defer completed.Add(1)
+			defer c.enter()()
+			if i%3 == 0 {
+				try.Check(fmt.Errorf("fn %d", i))
+			}
+		}
+	}
+	err := try.BatchAsync(3, fns...)
+	if p := c.peak.Load(); p > 3 {
+		t.Fatalf("%d functions at once, want no more than the batch size", p)
+	}
+	if n := len(try.Errors(err)); n != 3 {
+		t.Fatalf("got %d errors, want one per batch: %v", n, err)
+	}
+	if err := try.BatchAsync(0, func() {}, func() {}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestAsyncResults(t *testing.T) {
+	errs := try.AsyncResults(
+		func() error { time.Sleep(2 * time.Millisecond); return errTest },
+		func() error { return nil },
+		func() error { panic("boom") },
+	)
+	if len(errs) != 3 || errs[0] != errTest || errs[1] != nil || !errors.Is(errs[2], try.ErrPanic) {
+		t.Fatalf("got %v, want the outcomes in the order of the functions", errs)
+	}
+}
+
+func TestAsyncLabeled(t *testing.T) {
+	err := try.AsyncLabeled(
+		try.Task{Name: "fetch", Fn: func() { time.Sleep(2 * time.Millisecond); try.Check(errTest) }},
+		try.Task{Name: "parse", Fn: func() {}},
+		try.Task{Name: "store", Fn: func() { panic("boom") }},
+	)
+	errs := try.Errors(err)
+	if len(errs) != 2 || !strings.HasPrefix(errs[0].Error(), "task 'fetch': test error") || errs[1].Error() != "task 'store': boom" {
+		t.Fatalf("got %v, want the errors labeled in the order of the tasks", err)
+	}
+	if !errors.Is(err, errTest) || !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %v, want the original errors wrapped", err)
+	}
+}
+
+func TestWaitFirst(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	v, err := try.WaitFirst(
+		func() (int, error) { <-release; return 1, nil },
+		func() (int, error) { return 2, nil },
+	)
+	if v != 2 || err != nil {
+		t.Fatalf("got %d, %v, want 2, nil", v, err)
+	}
+
+	v, err = try.WaitFirst(
+		func() (int, error) { return 1, errTest },
+		func() (int, error) { panic("boom") },
+	)
+	if v != 0 || !errors.Is(err, errTest) || !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %d, %v", v, err)
+	}
+	if !strings.Contains(err.Error(), "index 0: test error") || !strings.Contains(err.Error(), "index 1: boom") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := try.WaitFirst[int](); err == nil {
+		t.Fatal("expected error without functions")
+	}
+}
+
+func TestParallelMap(t *testing.T) {
+	var c concurrency
+	in := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	out, err := try.ParallelMap(3, in, func(v int) (int, error) {
+		defer c.enter()()
+		return v * 10, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []int{10, 20, 30, 40, 50, 60, 70, 80}; !slices.Equal(out, want) {
+		t.Fatalf("got %v, want %v", out, want)
+	}
+	if p := c.peak.Load(); p > 3 {
+		t.Fatalf("%d calls at once, want no more than 3", p)
+	}
+
+	out, err = try.ParallelMap(0, in, func(v int) (int, error) {
+		switch v {
+		case 2:
+			return -1, errTest
+		case 5:
+			panic("boom")
+		}
+		return v, nil
+	})
+	if !errors.Is(err, errTest) || !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "index 1: test error") || !strings.Contains(msg, "index 4: boom") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []int{1, 0, 3, 4, 0, 6, 7, 8}; !slices.Equal(out, want) {
+		t.Fatalf("got %v, want %v", out, want)
+	}
+}
+
+func TestParallelMapFirstErr(t *testing.T) {
+	var calls atomic.Int32
+	in := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	out, err := try.ParallelMapFirstErr(1, in, func(v int) (int, error) {
+		calls.Add(1)
+		if v >= 3 {
+			return 0, fmt.Errorf("element %d", v)
+		}
+		return v, nil
+	})
+	if err == nil || !strings.HasPrefix(err.Error(), "index 2: element 3") || len(try.Errors(err)) != 1 {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Fatalf("got %d calls, want the elements after the failure skipped", n)
+	}
+	if want := []int{1, 2, 0, 0, 0, 0, 0, 0}; !slices.Equal(out, want) {
+		t.Fatalf("got %v, want %v", out, want)
+	}
+
+	out, err = try.ParallelMapFirstErr(0, in, func(v int) (int, error) { return v, nil })
+	if err != nil || !slices.Equal(out, in) {
+		t.Fatalf("got %v, %v", out, err)
+	}
+}
+
+func TestFanOut(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	out, err := try.FanOut(3, in, func(v int) (int, error) { return v * 10, nil })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	slices.Sort(out)
+	if want := []int{10, 20, 30, 40, 50, 60, 70, 80}; !slices.Equal(out, want) {
+		t.Fatalf("got %v, want %v", out, want)
+	}
+
+	out, err = try.FanOut(0, in, func(v int) (int, error) {
+		switch v {
+		case 2:
+			return 0, errTest
+		case 5:
+			panic("boom")
+		}
+		return v, nil
+	})
+	if !errors.Is(err, errTest) || !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	slices.Sort(out)
+	if want := []int{1, 3, 4, 6, 7, 8}; !slices.Equal(out, want) {
+		t.Fatalf("got %v, want %v", out, want)
+	}
+}
+
+func TestFanOutFirstErr(t *testing.T) {
+	var calls atomic.Int32
+	in := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	out, err := try.FanOutFirstErr(1, in, func(v int) (int, error) {
+		calls.Add(1)
+		if v >= 3 {
+			return 0, fmt.Errorf("element %d", v)
+		}
+		return v, nil
+	})
+	if err == nil || !strings.HasPrefix(err.Error(), "element 3") || len(try.Errors(err)) != 1 {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Fatalf("got %d calls, want the inputs after the failure skipped", n)
+	}
+	if want := []int{1, 2}; !slices.Equal(out, want) {
+		t.Fatalf("got %v, want %v", out, want)
+	}
+}
+
+func TestAsyncContext(t *testing.T) {
+	var cancelled atomic.Int32
+	wait := func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			cancelled.Add(1)
+			return ctx.Err()
+		case <-time.After(time.Second):
+			return nil
+		}
+	}
+	err := try.AsyncContext(context.Background(), wait, func(ctx context.Context) error {
+		panic(errTest)
+	}, wait)
+	if !errors.Is(err, errTest) || !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %v, want the panic with %v", err, errTest)
+	}
+	if n := cancelled.Load(); n != 2 {
+		t.Fatalf("%d functions observed the cancellation, want 2", n)
+	}
+
+	if err := try.AsyncContext(context.Background(), func(context.Context) error { return nil }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestAsync(t *testing.T) {
+	if err := try.Async(func() {}, func() {}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Run with -race: many functions panic concurrently.
+	fns := make([]func(), 100)
+	for i := range fns {
+		fns[i] = func() { panic(i) }
+	}
+	err := try.Async(fns...)
+	if n := len(try.Errors(err)); n != len(fns) {
+		t.Fatalf("got %d errors, want %d", n, len(fns))
+	}
+}
+
+func TestAsyncOrdered(t *testing.T) {
+	fns := make([]func(), 10)
+	for i := range fns {
+		fns[i] = func() {
+			time.Sleep(time.Duration(len(fns)-i) * time.Millisecond) // finish in reverse order
+			if i%3 != 0 {
+				try.Check(fmt.Errorf("fn %d", i))
+			}
+		}
+	}
+	err := try.AsyncOrdered(fns...)
+	var got []string
+	for _, e := range try.Errors(err) {
+		got = append(got, strings.SplitN(e.Error(), "\n", 2)[0])
+	}
+	if want := []string{"fn 1", "fn 2", "fn 4", "fn 5", "fn 7", "fn 8"}; !slices.Equal(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,79 @@
+package try_test
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+// Typical results (amd64):
+//
+//	BenchmarkVal    1 ns/op      0 B/op    0 allocs/op
+//	BenchmarkOK     1 ns/op      0 B/op    0 allocs/op
+//	BenchmarkCheck  1 ns/op      0 B/op    0 allocs/op, as fast as BenchmarkCheckHandwritten
+
+var sink int
+
+func BenchmarkVal(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sink = try.Val(i, nil)
+	}
+}
+
+func BenchmarkOK(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		try.OK(nil)
+	}
+}
+
+// BenchmarkCheck measures the nil path of the inlined checkErr.
+func BenchmarkCheck(b *testing.B) {
+	var err error
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		try.Check(err)
+	}
+}
+
+// BenchmarkCheckHandwritten is the baseline for BenchmarkCheck.
+func BenchmarkCheckHandwritten(b *testing.B) {
+	var err error
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestHappyPathAllocs(t *testing.T) {
+	parse := func() (int, error) { return strconv.Atoi("42") }
+	tests := map[string]func(){
+		"Val":   func() { sink = try.Val(parse()) },
+		"OK":    func() { try.OK(nil) },
+		"Check": func() { try.Check(nil) },
+	}
+	for name, fn := range tests {
+		if n := testing.AllocsPerRun(100, fn); n != 0 {
+			t.Errorf("%s: got %v allocs, want 0", name, n)
+		}
+	}
+}
+
+func TestColdPathLocation(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	errs := []error{
+		try.Call(func() { try.Val(0, errTest) }), // line+2
+		try.Call(func() { try.OK(errTest) }),     // line+3
+	}
+	for i, err := range errs {
+		if file, got, _ := try.Location(err); !strings.HasSuffix(file, "bench_test.go") || got != line+2+i {
+			t.Errorf("got %s:%d, want bench_test.go:%d", file, got, line+2+i)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package try
+
+import "runtime"
+
+// SafeSend sends v to ch and returns false instead of panicking if ch is closed.
+// The recovered panic is counted by RecoveredCount, other panics are re-raised.
+func SafeSend[T any](ch chan<- T, v T) (ok bool) {
+	defer recoverRuntime("send on closed channel")
+	ch <- v
+	return true
+}
+
+// SafeClose closes ch and returns false instead of panicking if ch is already closed.
+// Closing a nil channel still panics.
+func SafeClose[T any](ch chan T) (ok bool) {
+	defer recoverRuntime("close of closed channel")
+	close(ch)
+	return true
+}
+
+// ValChan receives a value from ch like v, ok := <-ch; ok is false if ch is closed and empty.
+// Unlike sends and closes, receives never panic, even from a closed channel.
+func ValChan[T any](ch <-chan T) (T, bool) {
+	v, ok := <-ch
+	return v, ok
+}
+
+// DrainChan receives all values from ch until it is closed.
+func DrainChan[T any](ch <-chan T) []T {
+	var vs []T
+	for v := range ch {
+		vs = append(vs, v)
+	}
+	return vs
+}
+
+// recoverRuntime recovers the runtime error with the given message like Catch does, other panics are re-raised.
+func recoverRuntime(msg string) {
+	if r := recover(); r != nil {
+		if e, ok := r.(runtime.Error); ok && e.Error() == msg {
+			notifyPanic(e)
+			return
+		}
+		panic(r)
+	}
+}
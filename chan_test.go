@@ -0,0 +1,61 @@
+package try_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+func TestSafeSend(t *testing.T) {
+	ch := make(chan int, 1)
+	if !try.SafeSend(ch, 1) || <-ch != 1 {
+		t.Fatal("send to an open channel failed")
+	}
+	close(ch)
+	if try.SafeSend(ch, 2) {
+		t.Fatal("got true for a closed channel")
+	}
+}
+
+func TestSafeClose(t *testing.T) {
+	ch := make(chan int)
+	if !try.SafeClose(ch) {
+		t.Fatal("got false for the first close")
+	}
+	if try.SafeClose(ch) {
+		t.Fatal("got true for a double close")
+	}
+
+	r := func() (r any) {
+		defer func() { r = recover() }()
+		try.SafeClose[int](nil)
+		return nil
+	}()
+	if err, ok := r.(error); !ok || err.Error() != "close of nil channel" {
+		t.Fatalf("got %v, want the nil channel panic propagated", r)
+	}
+}
+
+func TestValChan(t *testing.T) {
+	ch := make(chan int, 1)
+	ch <- 1
+	if v, ok := try.ValChan(ch); v != 1 || !ok {
+		t.Fatalf("got %d, %t, want 1, true", v, ok)
+	}
+	close(ch)
+	if v, ok := try.ValChan(ch); v != 0 || ok {
+		t.Fatalf("got %d, %t, want 0, false for a closed channel", v, ok)
+	}
+}
+
+func TestDrainChan(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+	if got := try.DrainChan(ch); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("got %v, want all values", got)
+	}
+}
@@ -0,0 +1,33 @@
+package try
+
+import "fmt"
+
+// Checkpoints tracks the current stage of a multi-stage operation to annotate its panics.
+//
+//	cp := try.NewCheckpoints()
+//	defer cp.Catch(&err)
+//	cp.At("loading index")
+type Checkpoints struct {
+	label string
+}
+
+// NewCheckpoints returns a new Checkpoints without a current stage.
+func NewCheckpoints() *Checkpoints {
+	return &Checkpoints{}
+}
+
+// At sets the label of the current stage.
+func (cp *Checkpoints) At(label string) {
+	cp.label = label
+}
+
+// Catch recovers and sets error by err pointer like the package-level Catch, annotated with the last stage like "during 'loading index': ...".
+func (cp *Checkpoints) Catch(err *error) {
+	if r := recover(); r != nil {
+		e := toError(r)
+		if cp.label != "" {
+			e = fmt.Errorf("during '%s': %w", cp.label, e)
+		}
+		catch(err, e)
+	}
+}
@@ -0,0 +1,43 @@
+package try_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+func TestCheckpoints(t *testing.T) {
+	run := func(stages int) (err error) {
+		cp := try.NewCheckpoints()
+		defer cp.Catch(&err)
+		if stages > 0 {
+			cp.At("loading index")
+		}
+		if stages > 1 {
+			cp.At("building cache")
+		}
+		try.Check(errTest)
+		return nil
+	}
+
+	err := run(2)
+	if !errors.Is(err, errTest) || !strings.HasPrefix(err.Error(), "during 'building cache': test error") {
+		t.Fatalf("got %v, want the error annotated with the last stage", err)
+	}
+	if err := run(0); !strings.HasPrefix(err.Error(), "test error") {
+		t.Fatalf("got %v, want the error without a stage", err)
+	}
+
+	logs := captureLog(t)
+	func() {
+		cp := try.NewCheckpoints()
+		defer cp.Catch(nil)
+		cp.At("loading index")
+		panic("boom")
+	}()
+	if len(*logs) != 1 || !strings.HasPrefix((*logs)[0], "Panic: during 'loading index': boom\n") || !strings.Contains((*logs)[0], "checkpoint_test.go:") {
+		t.Fatalf("got %q, want the annotated panic logged with its stack", *logs)
+	}
+}
@@ -0,0 +1,29 @@
+package try
+
+import "sync"
+
+var (
+	classifiersMx sync.RWMutex
+	classifiers   []func(err error) (category string, ok bool)
+)
+
+// RegisterClassifier registers a classifier used by Classify.
+// Classifiers are tried in registration order.
+func RegisterClassifier(fn func(err error) (category string, ok bool)) {
+	classifiersMx.Lock()
+	defer classifiersMx.Unlock()
+	classifiers = append(classifiers, fn)
+}
+
+// Classify returns the category of err from the first registered classifier that matches it, or "unknown".
+func Classify(err error) string {
+	classifiersMx.RLock()
+	cs := classifiers
+	classifiersMx.RUnlock()
+	for _, fn := range cs {
+		if category, ok := fn(err); ok {
+			return category
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,37 @@
+package try_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+var errDiskFull = errors.New("disk full")
+
+func TestClassify(t *testing.T) {
+	try.RegisterClassifier(func(err error) (string, bool) {
+		return "storage", errors.Is(err, errDiskFull)
+	})
+	try.RegisterClassifier(func(err error) (string, bool) {
+		return "panic", try.IsPanic(err)
+	})
+	try.RegisterClassifier(func(err error) (string, bool) {
+		return "shadowed", errors.Is(err, errDiskFull)
+	})
+
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{try.Call(func() { try.Check(fmt.Errorf("write: %w", errDiskFull)) }), "storage"},
+		{try.Call(func() { panic("boom") }), "panic"},
+		{errTest, "unknown"},
+	}
+	for _, tt := range tests {
+		if got := try.Classify(tt.err); got != tt.want {
+			t.Errorf("Classify(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
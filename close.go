@@ -0,0 +1,65 @@
+package try
+
+import "io"
+
+// Close closes closer and joins the close error into the error by err pointer.
+// When err is nil the close error is logged.
+func Close(closer io.Closer, err *error) {
+	if e := closer.Close(); e != nil {
+		if err == nil { // log error
+			logf("Close: %v", e)
+			return
+		}
+		*err = joinErrors(*err, e)
+	}
+}
+
+// CloseAll closes the closers in reverse order and joins all close errors into the error by err pointer.
+// Closing continues when a closer fails. When err is nil the close errors are logged.
+func CloseAll(err *error, closers ...io.Closer) {
+	for i := len(closers) - 1; i >= 0; i-- {
+		Close(closers[i], err)
+	}
+}
+
+// DeferErr recovers a panic of the function body, then runs the cleanup function fn safely
+// and joins the recovered panic-error and the error or panic of fn into the error by err pointer.
+// When err is nil the errors are logged.
+func DeferErr(fn func() error, err *error) {
+	if r := recover(); r != nil {
+		catch(err, r)
+	}
+	if e := CallResult(fn); e != nil {
+		if err == nil { // log error
+			logf("Cleanup: %v", e)
+			return
+		}
+		*err = joinErrors(*err, e)
+	}
+}
+
+// Deferred is a stack of cleanup functions. A zero Deferred is ready to use.
+type Deferred struct {
+	fns []func() error
+}
+
+// Add registers cleanup function fn.
+func (d *Deferred) Add(fn func() error) {
+	d.fns = append(d.fns, fn)
+}
+
+// Run calls the cleanup functions in reverse order and joins their errors and recovered panics
+// into the error by err pointer. A failing cleanup doesn't stop the others.
+// When err is nil the errors are logged.
+func (d *Deferred) Run(err *error) {
+	for i := len(d.fns) - 1; i >= 0; i-- {
+		if e := CallResult(d.fns[i]); e != nil {
+			if err == nil { // log error
+				logf("Cleanup: %v", e)
+				continue
+			}
+			*err = joinErrors(*err, e)
+		}
+	}
+	d.fns = nil
+}
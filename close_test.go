@@ -0,0 +1,108 @@
+package try_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+// closer records its Close calls in closed and returns err.
+type closer struct {
+	name   string
+	err    error
+	closed *[]string
+}
+
+func (c closer) Close() error {
+	*c.closed = append(*c.closed, c.name)
+	return c.err
+}
+
+func TestClose(t *testing.T) {
+	var closed []string
+	errPrev := errors.New("previous")
+	err := errPrev
+	try.Close(closer{"f", errTest, &closed}, &err)
+	if !errors.Is(err, errPrev) || !errors.Is(err, errTest) {
+		t.Fatalf("got %v, want the close error joined", err)
+	}
+
+	err = nil
+	try.Close(closer{"f", nil, &closed}, &err)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logs := captureLog(t)
+	try.Close(closer{"f", errTest, &closed}, nil)
+	if len(*logs) != 1 || !strings.Contains((*logs)[0], "test error") {
+		t.Fatalf("got %q, want the close error logged", *logs)
+	}
+}
+
+func TestCloseAll(t *testing.T) {
+	var closed []string
+	errA, errC := errors.New("a"), errors.New("c")
+	var err error
+	try.CloseAll(&err,
+		closer{"a", errA, &closed},
+		closer{"b", nil, &closed},
+		closer{"c", errC, &closed},
+	)
+	if want := []string{"c", "b", "a"}; !slices.Equal(closed, want) {
+		t.Fatalf("closed in order %v, want %v", closed, want)
+	}
+	if !errors.Is(err, errA) || !errors.Is(err, errC) {
+		t.Fatalf("got %v, want all close errors", err)
+	}
+}
+
+func TestDeferred(t *testing.T) {
+	var closed []string
+	run := func() (err error) {
+		var d try.Deferred
+		defer d.Run(&err)
+		d.Add(closer{"a", nil, &closed}.Close)
+		d.Add(func() error { panic("boom") })
+		d.Add(closer{"c", errTest, &closed}.Close)
+		return nil
+	}
+	err := run()
+	if !slices.Equal(closed, []string{"c", "a"}) {
+		t.Fatalf("got %v, want the cleanups in reverse order", closed)
+	}
+	if !errors.Is(err, errTest) || !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %v, want the error and the panic joined", err)
+	}
+
+	logs := captureLog(t)
+	var d try.Deferred
+	d.Add(func() error { return errTest })
+	d.Run(nil)
+	if len(*logs) != 1 || (*logs)[0] != "Cleanup: test error" {
+		t.Fatalf("got %q, want the error logged", *logs)
+	}
+}
+
+func TestDeferErr(t *testing.T) {
+	var closed []string
+	run := func(c closer, fn func()) (err error) {
+		defer try.DeferErr(c.Close, &err)
+		fn()
+		return nil
+	}
+
+	if err := run(closer{"a", nil, &closed}, func() {}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := run(closer{"b", errTest, &closed}, func() { panic("boom") })
+	if !errors.Is(err, errTest) || !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %v, want the panic and the cleanup error joined", err)
+	}
+	if !slices.Equal(closed, []string{"a", "b"}) {
+		t.Fatalf("closed %v, want the cleanup run every time", closed)
+	}
+}
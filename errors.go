@@ -0,0 +1,240 @@
+package try
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// ErrTry matches errors raised by the try helpers (OK, Check, Val, Require, ...).
+//
+//	if errors.Is(err, try.ErrTry) {
+//		// the error was propagated by try, not by a runtime panic
+//	}
+var ErrTry = errors.New("try: error")
+
+// ErrPanic matches errors converted from recovered panic values that are not errors,
+// e.g. panic("unexpected state"). Panics with error values keep their identity,
+// except in CallResult and the helpers built on it, which wrap them to match ErrPanic too.
+var ErrPanic = errors.New("try: panic")
+
+// ErrNilPointer is raised by ValDeref when a function returns a nil pointer without error.
+var ErrNilPointer = errors.New("try: nil pointer")
+
+// ErrOutOfRange is raised by ValRange when a value is outside the given bounds.
+var ErrOutOfRange = errors.New("try: out of range")
+
+// ErrTimeout is returned when a function doesn't complete within the given time.
+var ErrTimeout = errors.New("try: timeout")
+
+// panicError marks panics raised by checkErr.
+type panicError struct {
+	err   error
+	file  string
+	line  int
+	stack []uintptr
+}
+
+func (e *panicError) Error() string {
+	return e.err.Error()
+}
+
+func (e *panicError) Unwrap() error {
+	return e.err
+}
+
+func (e *panicError) Is(target error) bool {
+	return target == ErrTry
+}
+
+// locatedError is an error annotated with the location where a try helper raised it.
+type locatedError struct {
+	err  error
+	file string
+	line int
+}
+
+func (e *locatedError) Error() string {
+	return fmt.Sprintf("%v\n\t%s:%d", e.err, e.file, e.line)
+}
+
+func (e *locatedError) Unwrap() error {
+	return e.err
+}
+
+// Location returns the file and line where the error was raised.
+func (e *locatedError) Location() (file string, line int) {
+	return e.file, e.line
+}
+
+var panicFormatter atomic.Pointer[func(v any) string]
+
+// SetPanicFormatter sets the function which formats the panic values that are not errors, e.g. with "%+v".
+// A nil fn restores the default "%v" formatting.
+func SetPanicFormatter(fn func(v any) string) {
+	if fn == nil {
+		panicFormatter.Store(nil)
+		return
+	}
+	panicFormatter.Store(&fn)
+}
+
+// valueError is an error converted from a non-error panic value.
+type valueError struct {
+	v any
+}
+
+func (e *valueError) Error() string {
+	if fn := panicFormatter.Load(); fn != nil {
+		return (*fn)(e.v)
+	}
+	return fmt.Sprintf("%v", e.v)
+}
+
+func (e *valueError) Is(target error) bool {
+	return target == ErrPanic
+}
+
+// panickedError marks an error value recovered from a panic by CallResult.
+type panickedError struct {
+	err error
+}
+
+func (e *panickedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *panickedError) Unwrap() error {
+	return e.err
+}
+
+func (e *panickedError) Is(target error) bool {
+	return target == ErrPanic
+}
+
+// hintError is an error annotated with a remediation hint.
+type hintError struct {
+	err  error
+	hint string
+}
+
+func (e *hintError) Error() string {
+	return fmt.Sprintf("%v (hint: %s)", e.err, e.hint)
+}
+
+func (e *hintError) Unwrap() error {
+	return e.err
+}
+
+// Hint returns the remediation hint of the error.
+func (e *hintError) Hint() string {
+	return e.hint
+}
+
+// ErrorList is the error returned by the batch helpers (Async, Collect, WaitAll, ...) when several functions fail.
+// Like the result of errors.Join, it matches any of its errors with errors.Is and errors.As.
+// It is always used as a pointer, so that the error stays comparable with == and usable as a map key.
+type ErrorList struct {
+	errs []error
+}
+
+func (l *ErrorList) Error() string {
+	var b strings.Builder
+	for i, err := range l.errs {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+func (l *ErrorList) Unwrap() []error {
+	return l.errs
+}
+
+// Len returns the number of errors.
+func (l *ErrorList) Len() int {
+	return len(l.errs)
+}
+
+// At returns the i-th error.
+func (l *ErrorList) At(i int) error {
+	return l.errs[i]
+}
+
+// Filter returns the errors for which pred returns true.
+func (l *ErrorList) Filter(pred func(err error) bool) []error {
+	var errs []error
+	for _, err := range l.errs {
+		if pred(err) {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// Errors returns the errors joined in err (see errors.Join), recursively flattened.
+// It returns a single-element slice for an error that is not joined and nil for nil.
+func Errors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(interface{ Unwrap() []error }); ok {
+		var errs []error
+		for _, e := range e.Unwrap() {
+			errs = append(errs, Errors(e)...)
+		}
+		return errs
+	}
+	return []error{err}
+}
+
+// FirstAs returns the first of the errors joined in err that matches type T (see errors.As).
+func FirstAs[T error](err error) (T, bool) {
+	for _, e := range Errors(err) {
+		var target T
+		if errors.As(e, &target) {
+			return target, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Join returns the non-nil errors joined into an ErrorList, like errors.Join.
+// It returns nil if all errors are nil and the error itself if only one is non-nil.
+func Join(errs ...error) error {
+	var nonNil []error
+	for _, err := range errs {
+		if err != nil {
+			nonNil = append(nonNil, err)
+		}
+	}
+	return joinSlice(nonNil)
+}
+
+// Hint returns the remediation hint attached to err by CheckHint, or an empty string.
+func Hint(err error) string {
+	var e *hintError
+	if errors.As(err, &e) {
+		return e.hint
+	}
+	return ""
+}
+
+// IsPanic reports whether err was converted from a recovered panic value that is not an error.
+func IsPanic(err error) bool {
+	return errors.Is(err, ErrPanic)
+}
+
+// Location returns the file and line where err was raised by a try helper.
+// It reports false for errors that didn't come from try or if the location is disabled (see SetLocationFormat).
+func Location(err error) (file string, line int, ok bool) {
+	var e *panicError
+	if errors.As(err, &e) && e.line > 0 {
+		return e.file, e.line, true
+	}
+	return "", 0, false
+}
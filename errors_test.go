@@ -0,0 +1,158 @@
+package try_test
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+func TestErrPanic(t *testing.T) {
+	err := try.Call(func() { panic("unexpected state") })
+	if !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %v, want it to match ErrPanic", err)
+	}
+	if err.Error() != "unexpected state" {
+		t.Fatalf("got %q, want the panic value", err.Error())
+	}
+
+	err = try.Call(func() { panic(errTest) })
+	if err != errTest || errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %v, want %v unwrapped", err, errTest)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if errs := try.Errors(nil); errs != nil {
+		t.Fatalf("got %v, want nil", errs)
+	}
+	if errs := try.Errors(errTest); len(errs) != 1 || errs[0] != errTest {
+		t.Fatalf("got %v, want [%v]", errs, errTest)
+	}
+	errA, errB, errC := errors.New("a"), errors.New("b"), errors.New("c")
+	err := errors.Join(errA, errors.Join(errB, errC))
+	if errs := try.Errors(err); !slices.Equal(errs, []error{errA, errB, errC}) {
+		t.Fatalf("got %v, want the nested errors flattened", errs)
+	}
+
+	err = try.Async(func() { panic(errA) }, func() { panic(errB) })
+	if errs := try.Errors(err); len(errs) != 2 {
+		t.Fatalf("got %v, want both panics of Async", errs)
+	}
+}
+
+func TestIsPanic(t *testing.T) {
+	if !try.IsPanic(try.Call(func() { panic("boom") })) {
+		t.Fatal("got false for a raw panic value")
+	}
+	if try.IsPanic(try.Call(func() { try.Check(errTest) })) || try.IsPanic(nil) {
+		t.Fatal("got true for an error")
+	}
+}
+
+func TestLocatedError(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	err := try.Call(func() { try.Check(errTest) }) // line+1
+
+	var located interface{ Location() (string, int) }
+	if !errors.As(err, &located) {
+		t.Fatalf("got %#v, want a located error", err)
+	}
+	file, got := located.Location()
+	if !strings.HasSuffix(file, "errors_test.go") || got != line+1 {
+		t.Fatalf("got %s:%d, want errors_test.go:%d", file, got, line+1)
+	}
+	if want := fmt.Sprintf("test error\n\t%s:%d", file, got); err.Error() != want {
+		t.Fatalf("got %q, want %q", err.Error(), want)
+	}
+	if !errors.Is(err, errTest) {
+		t.Fatalf("got %v, want it to wrap %v", err, errTest)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	if err := try.Join(nil, nil); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if err := try.Join(nil, errTest, nil); err != errTest {
+		t.Fatalf("got %v, want the single error unwrapped", err)
+	}
+	errOther := errors.New("other")
+	err := try.Join(errTest, nil, errOther)
+	if errs := try.Errors(err); !slices.Equal(errs, []error{errTest, errOther}) {
+		t.Fatalf("got %v, want both errors", errs)
+	}
+}
+
+func TestFirstAs(t *testing.T) {
+	err := try.Async(
+		func() { try.Check(errTest) },
+		func() { try.Check(&validationError{field: "name"}) },
+	)
+	v, ok := try.FirstAs[*validationError](err)
+	if !ok || v == nil || v.field != "name" {
+		t.Fatalf("got %v, %t, want the validation error", v, ok)
+	}
+	if _, ok := try.FirstAs[*validationError](errTest); ok {
+		t.Fatal("got true without a matching error")
+	}
+}
+
+func TestSetPanicFormatter(t *testing.T) {
+	type point struct{ X, Y int }
+	try.SetPanicFormatter(func(v any) string { return fmt.Sprintf("%+v", v) })
+	t.Cleanup(func() { try.SetPanicFormatter(nil) })
+
+	err := try.Call(func() { panic(point{1, 2}) })
+	if err.Error() != "{X:1 Y:2}" || !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %q, want the custom format", err.Error())
+	}
+	if err := try.Call(func() { panic(errTest) }); err.Error() != "test error" {
+		t.Fatalf("got %q, want errors not formatted", err.Error())
+	}
+
+	try.SetPanicFormatter(nil)
+	if err := try.Call(func() { panic(point{1, 2}) }); err.Error() != "{1 2}" {
+		t.Fatalf("got %q, want the default format restored", err.Error())
+	}
+}
+
+func TestErrorList(t *testing.T) {
+	errOther := errors.New("other")
+	err := try.Async(func() { panic(errTest) }, func() { panic(errOther) }, func() { panic("boom") })
+	var list *try.ErrorList
+	if !errors.As(err, &list) || list.Len() != 3 {
+		t.Fatalf("got %#v, want an ErrorList of 3", err)
+	}
+	if !errors.Is(list, errTest) || !errors.Is(list, errOther) {
+		t.Fatalf("got %v, want it to match its errors", list)
+	}
+	if panics := list.Filter(try.IsPanic); len(panics) != 1 || panics[0].Error() != "boom" {
+		t.Fatalf("got %v, want the raw panic", panics)
+	}
+
+	if !errors.As(try.Join(errTest, errOther), &list) {
+		t.Fatal("Join didn't return an ErrorList")
+	}
+	if list.At(1) != errOther || list.Error() != "test error\nother" {
+		t.Fatalf("got %v at 1 of %q", list.At(1), list.Error())
+	}
+}
+
+func TestErrorListComparable(t *testing.T) {
+	err := try.Async(func() { panic("a") }, func() { panic("b") })
+	if err != err {
+		t.Fatal("joined error not equal to itself")
+	}
+	seen := map[error]int{err: 1}
+	if seen[err] != 1 {
+		t.Fatal("joined error not usable as a map key")
+	}
+	if other := try.Join(errTest, errTest); other == err {
+		t.Fatal("distinct joined errors are equal")
+	}
+}
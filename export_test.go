@@ -0,0 +1,10 @@
+package try
+
+import "io"
+
+// SetExit replaces the exit function and stderr used by Main and returns the function restoring them.
+func SetExit(fn func(code int), w io.Writer) (restore func()) {
+	prevExit, prevStderr := exit, stderr
+	exit, stderr = fn, w
+	return func() { exit, stderr = prevExit, prevStderr }
+}
@@ -0,0 +1,139 @@
+package try
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	contextKeysMx sync.RWMutex
+	contextKeys   []contextKey
+)
+
+type contextKey struct {
+	key   any
+	label string
+}
+
+// WithFields returns err annotated with fields, e.g. a request ID, for structured logging.
+// The fields are available via Fields.
+func WithFields(err error, fields map[string]any) error {
+	if err == nil {
+		return nil
+	}
+	return &fieldsError{err: err, fields: maps.Clone(fields)}
+}
+
+// Fields returns all fields attached to err and the errors it wraps, including the errors joined in it.
+// Fields attached later (outer) override the earlier ones, and of joined errors the first ones win.
+func Fields(err error) map[string]any {
+	var fields map[string]any
+	collectFields(err, &fields)
+	return fields
+}
+
+func collectFields(err error, fields *map[string]any) {
+	for err != nil {
+		if e, ok := err.(*fieldsError); ok {
+			if *fields == nil {
+				*fields = map[string]any{}
+			}
+			for k, v := range e.fields {
+				if _, ok := (*fields)[k]; !ok {
+					(*fields)[k] = v
+				}
+			}
+		}
+		if e, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range e.Unwrap() {
+				collectFields(e, fields)
+			}
+			return
+		}
+		err = errors.Unwrap(err)
+	}
+}
+
+// CatchFields recovers and sets error by err pointer, annotated with fields.
+func CatchFields(err *error, fields map[string]any) {
+	if r := recover(); r != nil {
+		if err == nil {
+			catch(nil, r)
+			return
+		}
+		*err = joinErrors(*err, WithFields(handlePanic(r), fields))
+	}
+}
+
+// RegisterContextKey registers a context key whose value CatchContext attaches to errors as the field label.
+func RegisterContextKey(key any, label string) {
+	contextKeysMx.Lock()
+	defer contextKeysMx.Unlock()
+	contextKeys = append(contextKeys, contextKey{key: key, label: label})
+}
+
+// CatchContext recovers and sets error by err pointer, annotated with the values of the registered context keys
+// found in ctx (see RegisterContextKey).
+func CatchContext(ctx context.Context, err *error) {
+	if r := recover(); r != nil {
+		if err == nil {
+			catch(nil, r)
+			return
+		}
+		*err = joinErrors(*err, withContext(ctx, handlePanic(r)))
+	}
+}
+
+func withContext(ctx context.Context, err error) error {
+	contextKeysMx.RLock()
+	keys := contextKeys
+	contextKeysMx.RUnlock()
+	fields := map[string]any{}
+	for _, k := range keys {
+		if v := ctx.Value(k.key); v != nil {
+			fields[k.label] = v
+		}
+	}
+	if len(fields) == 0 {
+		return err
+	}
+	return &fieldsError{err: err, fields: fields}
+}
+
+type fieldsError struct {
+	err    error
+	fields map[string]any
+}
+
+func (e *fieldsError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.err.Error())
+	b.WriteString(" [")
+	keys := make([]string, 0, len(e.fields))
+	for k := range e.fields {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%s=%v", k, e.fields[k])
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+func (e *fieldsError) Unwrap() error {
+	return e.err
+}
+
+// Fields returns the fields attached to the error.
+func (e *fieldsError) Fields() map[string]any {
+	return e.fields
+}
@@ -0,0 +1,78 @@
+package try_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+func TestFields(t *testing.T) {
+	base := errors.New("base")
+	err := try.WithFields(try.WithFields(base, map[string]any{"a": 1, "b": 1}), map[string]any{"b": 2})
+	if got, want := try.Fields(err), map[string]any{"a": 1, "b": 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := try.Fields(base); got != nil {
+		t.Fatalf("got %v, want nil", got)
+	}
+	if try.WithFields(nil, map[string]any{"a": 1}) != nil {
+		t.Fatal("expected nil")
+	}
+}
+
+func TestFieldsJoined(t *testing.T) {
+	err := errors.Join(
+		try.WithFields(errors.New("a"), map[string]any{"a": 1, "c": 1}),
+		try.WithFields(errors.New("b"), map[string]any{"b": 2, "c": 2}),
+	)
+	if got, want := try.Fields(err), map[string]any{"a": 1, "b": 2, "c": 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestCatchFields(t *testing.T) {
+	f := func() (err error) {
+		defer try.CatchFields(&err, map[string]any{"id": 7})
+		err = errors.New("returned")
+		panic("boom")
+	}
+	err := f()
+	if got := try.Fields(err); got["id"] != 7 {
+		t.Fatalf("got %v, want id=7", got)
+	}
+	if !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestFieldsAsync(t *testing.T) {
+	err := try.Async(func() {
+		try.Check(try.WithFields(errors.New("fail"), map[string]any{"task": "sync"}))
+	}, func() { panic("boom") })
+	if got := try.Fields(err); got["task"] != "sync" {
+		t.Fatalf("got %v, want task=sync", got)
+	}
+}
+
+type requestIDKey struct{}
+
+func TestCatchContext(t *testing.T) {
+	try.RegisterContextKey(requestIDKey{}, "request_id")
+	run := func(ctx context.Context) (err error) {
+		defer try.CatchContext(ctx, &err)
+		try.Check(errTest)
+		return nil
+	}
+
+	err := run(context.WithValue(context.Background(), requestIDKey{}, "r-1"))
+	if !errors.Is(err, errTest) || try.Fields(err)["request_id"] != "r-1" {
+		t.Fatalf("got %v with fields %v, want request_id=r-1", err, try.Fields(err))
+	}
+	err = run(context.Background())
+	if !errors.Is(err, errTest) || try.Fields(err)["request_id"] != nil {
+		t.Fatalf("got %v with fields %v, want no request_id", err, try.Fields(err))
+	}
+}
@@ -0,0 +1,64 @@
+package try
+
+import "fmt"
+
+// Chain runs the steps in order and returns the first error or recovered panic-error.
+// The remaining steps are skipped after a failure.
+func Chain(steps ...func() error) error {
+	for _, step := range steps {
+		if err := CallResult(step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Pipe passes in through fns in order and returns the final value.
+// It panics on the first error, annotated with the index of the failed stage.
+func Pipe[T any](in T, fns ...func(T) (T, error)) T {
+	for i, fn := range fns {
+		v, err := fn(in)
+		if err != nil {
+			checkErr(fmt.Errorf("stage %d: %w", i, err))
+		}
+		in = v
+	}
+	return in
+}
+
+// Waterfall passes initial through steps in order like Pipe, but returns the first error or recovered panic-error
+// instead of panicking, annotated with the index of the failed step, along with the last value produced.
+func Waterfall[T any](initial T, steps ...func(T) (T, error)) (T, error) {
+	v := initial
+	for i, step := range steps {
+		var next T
+		err := CallResult(func() (err error) {
+			next, err = step(v)
+			return
+		})
+		if err != nil {
+			return v, fmt.Errorf("step %d: %w", i, err)
+		}
+		v = next
+	}
+	return v, nil
+}
+
+// Coalesce calls the producers in order and returns the result of the first one that succeeds.
+// If all fail, their errors and recovered panics are annotated with the index and joined in order.
+func Coalesce[T any](producers ...func() (T, error)) (T, error) {
+	var errs []error
+	for i, produce := range producers {
+		var v T
+		err := CallResult(func() (err error) {
+			v, err = produce()
+			return
+		})
+		if err == nil {
+			return v, nil
+		}
+		errs = append(errs, indexError(i, err))
+	}
+	var zero T
+	return zero, joinSlice(errs)
+}
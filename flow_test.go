@@ -0,0 +1,97 @@
+package try_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+func TestChain(t *testing.T) {
+	var ran []int
+	step := func(i int, fn func() error) func() error {
+		return func() error {
+			ran = append(ran, i)
+			return fn()
+		}
+	}
+	ok := func() error { return nil }
+
+	if err := try.Chain(step(1, ok), step(2, ok)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ran = nil
+	err := try.Chain(step(1, ok), step(2, func() error { return errTest }), step(3, ok))
+	if err != errTest || !slices.Equal(ran, []int{1, 2}) {
+		t.Fatalf("got %v after steps %v, want %v after steps [1 2]", err, ran, errTest)
+	}
+
+	ran = nil
+	err = try.Chain(step(1, ok), step(2, func() error { panic("boom") }), step(3, ok))
+	if !errors.Is(err, try.ErrPanic) || !slices.Equal(ran, []int{1, 2}) {
+		t.Fatalf("got %v after steps %v, want the panic-error after steps [1 2]", err, ran)
+	}
+}
+
+func TestPipe(t *testing.T) {
+	double := func(v int) (int, error) { return v * 2, nil }
+	inc := func(v int) (int, error) { return v + 1, nil }
+	fail := func(int) (int, error) { return 0, errTest }
+
+	var got int
+	if err := try.Call(func() { got = try.Pipe(1, double, inc, double) }); err != nil || got != 6 {
+		t.Fatalf("got %d, %v, want 6, nil", got, err)
+	}
+	if got := try.Pipe(5); got != 5 {
+		t.Fatalf("got %d, want the input without stages", got)
+	}
+	err := try.Call(func() { try.Pipe(1, double, fail, inc) })
+	if !errors.Is(err, errTest) || !strings.HasPrefix(err.Error(), "stage 1: ") {
+		t.Fatalf("got %v, want the error of stage 1", err)
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	var tried []string
+	producer := func(name string, v int, err error) func() (int, error) {
+		return func() (int, error) {
+			tried = append(tried, name)
+			return v, err
+		}
+	}
+
+	v, err := try.Coalesce(
+		producer("cache", 0, errTest),
+		func() (int, error) { tried = append(tried, "db"); panic("boom") },
+		producer("remote", 3, nil),
+		producer("unused", 4, nil),
+	)
+	if v != 3 || err != nil || !slices.Equal(tried, []string{"cache", "db", "remote"}) {
+		t.Fatalf("got %d, %v after %v, want 3 from remote", v, err, tried)
+	}
+
+	v, err = try.Coalesce(producer("cache", 1, errTest), producer("db", 2, errTest))
+	if v != 0 || !strings.HasPrefix(err.Error(), "index 0: test error") || len(try.Errors(err)) != 2 {
+		t.Fatalf("got %d, %v, want 0 and both errors", v, err)
+	}
+}
+
+func TestWaterfall(t *testing.T) {
+	double := func(v int) (int, error) { return v * 2, nil }
+	v, err := try.Waterfall(1, double, double)
+	if v != 4 || err != nil {
+		t.Fatalf("got %d, %v, want 4, nil", v, err)
+	}
+
+	v, err = try.Waterfall(1, double, func(int) (int, error) { panic("boom") }, double)
+	if v != 2 || !errors.Is(err, try.ErrPanic) || !strings.HasPrefix(err.Error(), "step 1: boom") {
+		t.Fatalf("got %d, %v, want the value so far and the error of step 1", v, err)
+	}
+	v, err = try.Waterfall(1, func(int) (int, error) { return 99, errTest })
+	if v != 1 || !errors.Is(err, errTest) {
+		t.Fatalf("got %d, %v, want the initial value and %v", v, err, errTest)
+	}
+}
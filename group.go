@@ -0,0 +1,134 @@
+package try
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Group runs functions in goroutines and collects their errors and panics, like errgroup.Group.
+// A zero Group is valid and does not cancel on error.
+type Group struct {
+	wg     sync.WaitGroup
+	mx     sync.Mutex
+	err    error
+	cancel context.CancelFunc
+}
+
+// WithContext returns a new Group and a derived context,
+// which is cancelled on the first error or panic, or when Wait returns.
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{cancel: cancel}, ctx
+}
+
+// Go runs fn in a new goroutine. The returned error or recovered panic is collected by the group.
+func (g *Group) Go(fn func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := CallResult(fn); err != nil {
+			g.mx.Lock()
+			defer g.mx.Unlock()
+			g.err = joinErrors(g.err, err)
+			if g.cancel != nil {
+				g.cancel()
+			}
+		}
+	}()
+}
+
+// Wait blocks until all functions have completed and returns the joined error of all failures.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	g.mx.Lock()
+	defer g.mx.Unlock()
+	return g.err
+}
+
+// Tracker runs functions in goroutines and collects their panics, like Async,
+// but functions can be added incrementally. A zero Tracker is ready to use.
+type Tracker struct {
+	wg  sync.WaitGroup
+	mx  sync.Mutex
+	err error
+}
+
+// Add runs fn safely in a new goroutine tracked by t.
+func (t *Tracker) Add(fn func()) {
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		if err := Call(fn); err != nil {
+			t.mx.Lock()
+			defer t.mx.Unlock()
+			t.err = joinErrors(t.err, err)
+		}
+	}()
+}
+
+// Wait blocks until all added functions have completed and returns the joined error of their panics.
+func (t *Tracker) Wait() error {
+	t.wg.Wait()
+	t.mx.Lock()
+	defer t.mx.Unlock()
+	return t.err
+}
+
+// ErrGroupFunc returns a function for errgroup.Group.Go, which runs fn and returns its recovered panic-error
+// instead of crashing the program.
+func ErrGroupFunc(fn func()) func() error {
+	return Safe(fn)
+}
+
+var (
+	trackedMx    sync.Mutex
+	trackedCount int           // number of running goroutines started by GoTracked
+	trackedIdle  chan struct{} // closed when trackedCount drops to zero
+)
+
+// GoTracked runs the function safely like Go, tracked for Wait.
+func GoTracked(fn func()) {
+	trackedMx.Lock()
+	if trackedCount == 0 {
+		trackedIdle = make(chan struct{})
+	}
+	trackedCount++
+	trackedMx.Unlock()
+	Go(func() {
+		defer func() {
+			trackedMx.Lock()
+			defer trackedMx.Unlock()
+			if trackedCount--; trackedCount == 0 {
+				close(trackedIdle)
+			}
+		}()
+		fn()
+	})
+}
+
+// Wait blocks until all goroutines started by GoTracked have completed,
+// or returns ErrTimeout if they don't complete within timeout.
+func Wait(timeout time.Duration) error {
+	trackedMx.Lock()
+	idle := trackedIdle
+	running := trackedCount > 0
+	trackedMx.Unlock()
+	if !running {
+		return nil
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-idle:
+		return nil
+	case <-timer.C:
+		trackedMx.Lock()
+		defer trackedMx.Unlock()
+		return fmt.Errorf("%w: %d goroutines still running", ErrTimeout, trackedCount)
+	}
+}
@@ -0,0 +1,117 @@
+package try_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goldic/try"
+)
+
+func TestWait(t *testing.T) {
+	if err := try.Wait(time.Millisecond); err != nil {
+		t.Fatalf("unexpected error without goroutines: %v", err)
+	}
+
+	release := make(chan struct{})
+	for range 2 {
+		try.GoTracked(func() { <-release })
+	}
+	before := runtime.NumGoroutine()
+	for range 10 {
+		err := try.Wait(time.Millisecond)
+		if !errors.Is(err, try.ErrTimeout) || !strings.Contains(err.Error(), "2 goroutines still running") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Fatalf("timed out waits left %d goroutines behind", n-before)
+	}
+
+	close(release)
+	if err := try.Wait(time.Second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGroup(t *testing.T) {
+	var g try.Group
+	g.Go(func() error { return nil })
+	g.Go(func() error { return errTest })
+	g.Go(func() error { panic("boom") })
+	err := g.Wait()
+	if !errors.Is(err, errTest) || !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %v, want both the returned error and the panic", err)
+	}
+}
+
+func TestGroupWithContext(t *testing.T) {
+	g, ctx := try.WithContext(context.Background())
+	g.Go(func() error {
+		<-ctx.Done()
+		return nil
+	})
+	g.Go(func() error { panic(errTest) })
+	if err := g.Wait(); !errors.Is(err, errTest) || !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %v, want the panic with %v", err, errTest)
+	}
+	if ctx.Err() == nil {
+		t.Fatal("context not cancelled")
+	}
+}
+
+func TestTracker(t *testing.T) {
+	var tr try.Tracker
+	tr.Add(func() {})
+	tr.Add(func() { try.Check(errTest) })
+	for range 3 {
+		tr.Add(func() { panic("boom") })
+	}
+	err := tr.Wait()
+	if n := len(try.Errors(err)); n != 4 || !errors.Is(err, errTest) || !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %d errors, want all 4 panics joined: %v", n, err)
+	}
+
+	var empty try.Tracker
+	if err := empty.Wait(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestErrGroupFunc(t *testing.T) {
+	fn := try.ErrGroupFunc(func() { panic("boom") })
+	if err := fn(); !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %v, want the panic-error", err)
+	}
+	if err := try.ErrGroupFunc(func() {})(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGoCtx(t *testing.T) {
+	try.RegisterContextKey(requestIDKey{}, "request_id")
+	logged := make(chan string, 1)
+	try.SetLogger(func(format string, args ...any) { logged <- fmt.Sprintf(format, args...) })
+	t.Cleanup(func() { try.SetLogger(nil) })
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "r-1")
+	got := make(chan any, 1)
+	try.GoCtx(ctx, func(ctx context.Context) { got <- ctx.Value(requestIDKey{}) })
+	if v := <-got; v != "r-1" {
+		t.Fatalf("got %v, want the context passed through", v)
+	}
+
+	try.GoCtx(ctx, func(context.Context) { panic("boom") })
+	select {
+	case msg := <-logged:
+		if !strings.HasPrefix(msg, "Panic: boom") || !strings.Contains(msg, "r-1") {
+			t.Fatalf("got %q, want the panic logged with the context values", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("panic not logged")
+	}
+}
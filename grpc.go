@@ -0,0 +1,93 @@
+package try
+
+import (
+	"errors"
+	"reflect"
+)
+
+// GRPCCoder is implemented by errors which carry a gRPC status code.
+// The code is returned as uint32(codes.Code), so the package doesn't depend on gRPC.
+// GRPCCode and CatchGRPC also accept errors whose GRPCCode method returns another
+// uint32-based type such as codes.Code, although those don't implement GRPCCoder.
+type GRPCCoder interface {
+	error
+	GRPCCode() uint32
+}
+
+// grpcInternal is the value of codes.Internal.
+const grpcInternal = 13
+
+// GRPCCode returns the gRPC status code of err: 0 (codes.OK) for nil,
+// the code of a GRPCCoder, or 13 (codes.Internal) otherwise.
+func GRPCCode(err error) uint32 {
+	if err == nil {
+		return 0
+	}
+	if code, ok := grpcCodeOf(err); ok {
+		return code
+	}
+	return grpcInternal
+}
+
+// grpcCodeOf returns the code of the first error in the tree of err with a GRPCCode method.
+func grpcCodeOf(err error) (uint32, bool) {
+	var e GRPCCoder
+	if errors.As(err, &e) {
+		return e.GRPCCode(), true
+	}
+	return typedGRPCCode(err)
+}
+
+// typedGRPCCode finds a GRPCCode method returning a uint32-based type, like codes.Code, by reflection.
+func typedGRPCCode(err error) (uint32, bool) {
+	for err != nil {
+		m := reflect.ValueOf(err).MethodByName("GRPCCode")
+		if m.IsValid() && m.Type().NumIn() == 0 && m.Type().NumOut() == 1 && m.Type().Out(0).Kind() == reflect.Uint32 {
+			return uint32(m.Call(nil)[0].Uint()), true
+		}
+		if e, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range e.Unwrap() {
+				if code, ok := typedGRPCCode(e); ok {
+					return code, true
+				}
+			}
+			return 0, false
+		}
+		err = errors.Unwrap(err)
+	}
+	return 0, false
+}
+
+// CatchGRPC recovers and sets error by err pointer. The error always carries a gRPC status code:
+// its own if it has a GRPCCode method (see GRPCCoder), or codes.Internal otherwise.
+func CatchGRPC(err *error) {
+	if r := recover(); r != nil {
+		if err == nil {
+			catch(nil, r)
+			return
+		}
+		e := handlePanic(r)
+		if _, ok := grpcCodeOf(e); !ok {
+			e = &codeError{err: e, code: grpcInternal}
+		}
+		*err = joinErrors(*err, e)
+	}
+}
+
+// codeError attaches a gRPC status code to an error.
+type codeError struct {
+	err  error
+	code uint32
+}
+
+func (e *codeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codeError) Unwrap() error {
+	return e.err
+}
+
+func (e *codeError) GRPCCode() uint32 {
+	return e.code
+}
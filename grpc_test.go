@@ -0,0 +1,70 @@
+package try_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+// codeError is an error with a gRPC status code.
+type codeError uint32
+
+func (e codeError) Error() string    { return "code error" }
+func (e codeError) GRPCCode() uint32 { return uint32(e) }
+
+// code mimics codes.Code, a named uint32 type.
+type code uint32
+
+// typedCodeError is an error whose GRPCCode method returns a named code type like status errors do.
+type typedCodeError struct{ code code }
+
+func (e typedCodeError) Error() string  { return "typed code error" }
+func (e typedCodeError) GRPCCode() code { return e.code }
+
+func TestGRPCCode(t *testing.T) {
+	const notFound, internal = 5, 13
+	if got := try.GRPCCode(nil); got != 0 {
+		t.Fatalf("got %d, want 0", got)
+	}
+	if got := try.GRPCCode(fmt.Errorf("wrapped: %w", codeError(notFound))); got != notFound {
+		t.Fatalf("got %d, want %d", got, notFound)
+	}
+	if got := try.GRPCCode(fmt.Errorf("wrapped: %w", typedCodeError{notFound})); got != notFound {
+		t.Fatalf("got %d, want %d for a named code type", got, notFound)
+	}
+	if got := try.GRPCCode(errors.Join(errTest, typedCodeError{notFound})); got != notFound {
+		t.Fatalf("got %d, want %d from a joined error", got, notFound)
+	}
+	if got := try.GRPCCode(errTest); got != internal {
+		t.Fatalf("got %d, want %d", got, internal)
+	}
+}
+
+func TestCatchGRPC(t *testing.T) {
+	run := func(fn func()) (err error) {
+		defer try.CatchGRPC(&err)
+		fn()
+		return nil
+	}
+
+	if err := run(func() {}); err != nil || try.GRPCCode(err) != 0 {
+		t.Fatalf("got %v, want nil", err)
+	}
+	const notFound = 5
+	err := run(func() { try.Check(codeError(notFound)) })
+	if !errors.Is(err, codeError(notFound)) || try.GRPCCode(err) != notFound {
+		t.Fatalf("got %v with code %d, want code %d", err, try.GRPCCode(err), notFound)
+	}
+	err = run(func() { try.Check(typedCodeError{notFound}) })
+	if !errors.Is(err, typedCodeError{notFound}) || try.GRPCCode(err) != notFound {
+		t.Fatalf("got %v with code %d, want code %d", err, try.GRPCCode(err), notFound)
+	}
+	const internal = 13
+	err = run(func() { panic("boom") })
+	var c try.GRPCCoder
+	if !errors.As(err, &c) || c.GRPCCode() != internal || !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %v, want the panic-error with code %d", err, internal)
+	}
+}
@@ -0,0 +1,33 @@
+package try
+
+import "sync"
+
+var (
+	hooksMx sync.RWMutex
+	hooks   []func(err error)
+)
+
+// OnPanic registers hook to be called with every panic recovered by Catch, Call, Go, Async and the other recovering helpers.
+// Hooks are called in registration order, before the normal handling of the panic.
+func OnPanic(hook func(err error)) {
+	hooksMx.Lock()
+	defer hooksMx.Unlock()
+	hooks = append(hooks, hook)
+}
+
+// handlePanic converts the recovered value r to an error and notifies the panic hooks.
+func handlePanic(r any) error {
+	err := toError(r)
+	notifyPanic(err)
+	return err
+}
+
+func notifyPanic(err error) {
+	recoveredCount.Add(1)
+	hooksMx.RLock()
+	hs := hooks
+	hooksMx.RUnlock()
+	for _, hook := range hs {
+		hook(err)
+	}
+}
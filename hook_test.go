@@ -0,0 +1,89 @@
+package try_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goldic/try"
+)
+
+var (
+	hookOnce sync.Once
+	testHook atomic.Pointer[func(err error)]
+)
+
+// setHook routes the panics recovered by try to hook until the end of the test.
+// Hooks can't be unregistered, so a single forwarding hook is registered for all tests.
+func setHook(t *testing.T, hook func(err error)) {
+	t.Helper()
+	hookOnce.Do(func() {
+		try.OnPanic(func(err error) {
+			if h := testHook.Load(); h != nil {
+				(*h)(err)
+			}
+		})
+	})
+	testHook.Store(&hook)
+	t.Cleanup(func() { testHook.Store(nil) })
+}
+
+func TestOnPanic(t *testing.T) {
+	var order []int
+	for i := range 2 {
+		try.OnPanic(func(err error) {
+			if errors.Is(err, errOrder) {
+				order = append(order, i)
+			}
+		})
+	}
+	try.Call(func() { try.Check(errOrder) })
+	if len(order) != 2 || order[0] != 0 || order[1] != 1 {
+		t.Fatalf("hooks called in order %v, want [0 1]", order)
+	}
+}
+
+var errOrder = errors.New("order")
+
+func TestOnPanicEntryPoints(t *testing.T) {
+	done := make(chan struct{})
+	for name, run := range map[string]func(){
+		"Catch": func() {
+			var err error
+			func() {
+				defer try.Catch(&err)
+				try.Check(errTest)
+			}()
+		},
+		"Call":  func() { try.Call(func() { try.Check(errTest) }) },
+		"Async": func() { try.Async(func() { try.Check(errTest) }, func() {}) },
+		"Go": func() {
+			try.Go(func() { try.Check(errTest) })
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+			}
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var mx sync.Mutex
+			var errs []error
+			setHook(t, func(err error) {
+				mx.Lock()
+				errs = append(errs, err)
+				mx.Unlock()
+				if name == "Go" {
+					done <- struct{}{}
+				}
+			})
+			run()
+			mx.Lock()
+			defer mx.Unlock()
+			if len(errs) != 1 || !errors.Is(errs[0], errTest) {
+				t.Fatalf("hook called with %v, want %v once", errs, errTest)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package try
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// StatusError is implemented by errors which carry an HTTP status code.
+type StatusError interface {
+	error
+	StatusCode() int
+}
+
+// CatchStatus recovers, sets error by err pointer and sets the HTTP status code of the error by status pointer.
+// The status is 500 if the error doesn't implement StatusError.
+func CatchStatus(err *error, status *int) {
+	if r := recover(); r != nil {
+		e := catch(err, r)
+		if status != nil {
+			*status = statusCode(e)
+		}
+	}
+}
+
+func statusCode(err error) int {
+	var e StatusError
+	if errors.As(err, &e) {
+		return e.StatusCode()
+	}
+	return http.StatusInternalServerError
+}
+
+// CatchRetryable recovers, sets error by err pointer and sets by retryable pointer whether the error is transient:
+// it reports Temporary() or Timeout() true, is a StatusError with a 5xx status code, or matches ErrTimeout
+// or context.DeadlineExceeded.
+func CatchRetryable(err *error, retryable *bool) {
+	if r := recover(); r != nil {
+		e := catch(err, r)
+		if retryable != nil {
+			*retryable = isRetryable(e)
+		}
+	}
+}
+
+func isRetryable(err error) bool {
+	var temporary interface{ Temporary() bool }
+	if errors.As(err, &temporary) && temporary.Temporary() {
+		return true
+	}
+	var timeout interface{ Timeout() bool }
+	if errors.As(err, &timeout) && timeout.Timeout() {
+		return true
+	}
+	var status StatusError
+	if errors.As(err, &status) && status.StatusCode() >= 500 {
+		return true
+	}
+	return errors.Is(err, ErrTimeout) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// HTTPRecover returns a handler which recovers panics in next, logs them and responds with status 500,
+// or the status of a StatusError, unless the response has already been started.
+// http.ErrAbortHandler is re-raised to abort the response as usual.
+// The writer passed to next implements http.Flusher and http.Hijacker.
+func HTTPRecover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &responseWriter{ResponseWriter: w}
+		defer func() {
+			if v := recover(); v != nil {
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+				err := handlePanic(v)
+				logf("Panic: %v", err)
+				if !rw.written {
+					status := statusCode(err)
+					http.Error(w, http.StatusText(status), status)
+				}
+			}
+		}()
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// responseWriter tracks whether the response has been started.
+type responseWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	w.written = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Flush implements http.Flusher for streaming handlers, e.g. server-sent events.
+func (w *responseWriter) Flush() {
+	w.written = true
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Hijack implements http.Hijacker for handlers taking over the connection, e.g. websockets.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(w.ResponseWriter).Hijack()
+	if err == nil {
+		w.written = true
+	}
+	return conn, rw, err
+}
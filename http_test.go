@@ -0,0 +1,162 @@
+package try_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+type statusError int
+
+func (e statusError) Error() string   { return http.StatusText(int(e)) }
+func (e statusError) StatusCode() int { return int(e) }
+
+func serve(h http.HandlerFunc) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	try.HTTPRecover(h).ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	return w
+}
+
+func TestHTTPRecover(t *testing.T) {
+	logs := captureLog(t)
+	w := serve(func(w http.ResponseWriter, r *http.Request) { panic("boom") })
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("got status %d, want 500", w.Code)
+	}
+	if len(*logs) != 1 || !strings.Contains((*logs)[0], "boom") {
+		t.Fatalf("unexpected logs: %q", *logs)
+	}
+
+	w = serve(func(w http.ResponseWriter, r *http.Request) { try.Check(statusError(http.StatusNotFound)) })
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("got status %d, want 404", w.Code)
+	}
+
+	w = serve(func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("ok")) })
+	if w.Code != http.StatusOK || w.Body.String() != "ok" {
+		t.Fatalf("got %d %q, want 200 ok", w.Code, w.Body)
+	}
+}
+
+func TestHTTPRecoverWritten(t *testing.T) {
+	captureLog(t)
+	w := serve(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("partial"))
+		panic("boom")
+	})
+	if w.Code != http.StatusAccepted || w.Body.String() != "partial" {
+		t.Fatalf("got %d %q, want 202 partial", w.Code, w.Body)
+	}
+}
+
+func TestHTTPRecoverAbort(t *testing.T) {
+	err := try.Call(func() {
+		serve(func(w http.ResponseWriter, r *http.Request) { panic(http.ErrAbortHandler) })
+	})
+	if err != http.ErrAbortHandler {
+		t.Fatalf("got %v, want http.ErrAbortHandler", err)
+	}
+}
+
+func TestHTTPRecoverFlusher(t *testing.T) {
+	w := serve(func(w http.ResponseWriter, r *http.Request) {
+		f, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("writer doesn't implement http.Flusher")
+		}
+		w.Write([]byte("event"))
+		f.Flush()
+	})
+	if !w.Flushed {
+		t.Fatal("not flushed")
+	}
+}
+
+func TestHTTPRecoverHijacker(t *testing.T) {
+	srv := httptest.NewServer(try.HTTPRecover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		rw.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 8\r\n\r\nhijacked")
+		rw.Flush()
+	})))
+	defer srv.Close()
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	line, _ := bufio.NewReader(resp.Body).ReadString('\n')
+	if line != "hijacked" {
+		t.Fatalf("got %q, want hijacked", line)
+	}
+}
+
+func TestCatchStatus(t *testing.T) {
+	run := func(fn func()) (status int, err error) {
+		defer try.CatchStatus(&err, &status)
+		fn()
+		return http.StatusOK, nil
+	}
+
+	status, err := run(func() {})
+	if err != nil || status != http.StatusOK {
+		t.Fatalf("got %v, %d, want nil, 200", err, status)
+	}
+	status, err = run(func() { try.Check(statusError(http.StatusConflict)) })
+	if !errors.Is(err, statusError(http.StatusConflict)) || status != http.StatusConflict {
+		t.Fatalf("got %v, %d, want the status error and 409", err, status)
+	}
+	status, err = run(func() { try.Check(fmt.Errorf("wrapped: %w", statusError(http.StatusNotFound))) })
+	if status != http.StatusNotFound {
+		t.Fatalf("got %d, want 404 from the wrapped error", status)
+	}
+	status, err = run(func() { panic("boom") })
+	if !errors.Is(err, try.ErrPanic) || status != http.StatusInternalServerError {
+		t.Fatalf("got %v, %d, want the panic-error and 500", err, status)
+	}
+}
+
+// temporaryError reports whether it is temporary.
+type temporaryError bool
+
+func (e temporaryError) Error() string   { return "temporary" }
+func (e temporaryError) Temporary() bool { return bool(e) }
+
+func TestCatchRetryable(t *testing.T) {
+	run := func(v any) (retryable bool, err error) {
+		defer try.CatchRetryable(&err, &retryable)
+		panic(v)
+	}
+	tests := []struct {
+		name string
+		v    any
+		want bool
+	}{
+		{"temporary", temporaryError(true), true},
+		{"not temporary", temporaryError(false), false},
+		{"5xx", statusError(http.StatusBadGateway), true},
+		{"4xx", statusError(http.StatusBadRequest), false},
+		{"timeout", try.ErrTimeout, true},
+		{"deadline", fmt.Errorf("call: %w", context.DeadlineExceeded), true},
+		{"other", errTest, false},
+		{"raw panic", "boom", false},
+	}
+	for _, tt := range tests {
+		retryable, err := run(tt.v)
+		if err == nil || retryable != tt.want {
+			t.Errorf("%s: got %t, %v, want %t", tt.name, retryable, err, tt.want)
+		}
+	}
+}
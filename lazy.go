@@ -0,0 +1,65 @@
+package try
+
+import "sync"
+
+// Lazy is a value initialized on first use. Panics of the initializer are recovered as errors.
+type Lazy[T any] struct {
+	once sync.Once
+	init func() (T, error)
+	v    T
+	err  error
+}
+
+// NewLazy returns a Lazy value initialized by init.
+func NewLazy[T any](init func() (T, error)) *Lazy[T] {
+	return &Lazy[T]{init: init}
+}
+
+// Get runs the initializer once and returns its result, the same for every call.
+func (l *Lazy[T]) Get() (T, error) {
+	l.once.Do(func() {
+		l.err = CallResult(func() (err error) {
+			l.v, err = l.init()
+			return
+		})
+	})
+	return l.v, l.err
+}
+
+// Memoize returns a wrapper of fn that caches successful results by key.
+// Concurrent calls with the same key share one call of fn. Errors and recovered panics are not cached.
+func Memoize[K comparable, V any](fn func(K) (V, error)) func(K) (V, error) {
+	type entry struct {
+		wg  sync.WaitGroup
+		v   V
+		err error
+	}
+	var (
+		mx    sync.Mutex
+		cache = map[K]*entry{}
+	)
+	return func(key K) (V, error) {
+		mx.Lock()
+		if e, ok := cache[key]; ok {
+			mx.Unlock()
+			e.wg.Wait()
+			return e.v, e.err
+		}
+		e := &entry{}
+		e.wg.Add(1)
+		cache[key] = e
+		mx.Unlock()
+
+		e.err = CallResult(func() (err error) {
+			e.v, err = fn(key)
+			return
+		})
+		if e.err != nil {
+			mx.Lock()
+			delete(cache, key)
+			mx.Unlock()
+		}
+		e.wg.Done()
+		return e.v, e.err
+	}
+}
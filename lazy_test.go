@@ -0,0 +1,84 @@
+package try_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+func TestLazy(t *testing.T) {
+	calls := 0
+	l := try.NewLazy(func() (int, error) {
+		calls++
+		return 42, nil
+	})
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := l.Get(); v != 42 || err != nil {
+				t.Errorf("got %d, %v, want 42, nil", v, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls != 1 {
+		t.Fatalf("initializer called %d times, want once", calls)
+	}
+
+	calls = 0
+	l = try.NewLazy(func() (int, error) {
+		calls++
+		panic("boom")
+	})
+	for range 2 {
+		if _, err := l.Get(); !errors.Is(err, try.ErrPanic) {
+			t.Fatalf("got %v, want the cached panic-error", err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("panicking initializer called %d times, want once", calls)
+	}
+}
+
+func TestMemoize(t *testing.T) {
+	var calls atomic.Int32
+	fail := true
+	square := try.Memoize(func(v int) (int, error) {
+		calls.Add(1)
+		if v < 0 && fail {
+			panic("negative")
+		}
+		return v * v, nil
+	})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := square(3); v != 9 || err != nil {
+				t.Errorf("got %d, %v, want 9, nil", v, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("fn called %d times for one key, want once", n)
+	}
+
+	if _, err := square(-2); !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %v, want the panic-error", err)
+	}
+	fail = false
+	if v, err := square(-2); v != 4 || err != nil {
+		t.Fatalf("got %d, %v, want the failure retried", v, err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Fatalf("fn called %d times, want 3", n)
+	}
+}
@@ -0,0 +1,18 @@
+package try
+
+import "sync/atomic"
+
+// goSem limits the number of goroutines started by Go, nil means no limit.
+var goSem atomic.Pointer[chan struct{}]
+
+// SetMaxGoroutines limits the number of goroutines started by Go that run at once.
+// When the limit is reached, Go blocks until one of them completes. A limit less than 1 means no limit.
+// Goroutines already running count against the limit they were started with.
+func SetMaxGoroutines(n int) {
+	if n < 1 {
+		goSem.Store(nil)
+		return
+	}
+	sem := make(chan struct{}, n)
+	goSem.Store(&sem)
+}
@@ -0,0 +1,27 @@
+package try_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+func TestSetMaxGoroutines(t *testing.T) {
+	try.SetMaxGoroutines(2)
+	t.Cleanup(func() { try.SetMaxGoroutines(0) })
+
+	var c concurrency
+	var wg sync.WaitGroup
+	wg.Add(10)
+	for range 10 {
+		try.Go(func() {
+			defer wg.Done()
+			defer c.enter()()
+		})
+	}
+	wg.Wait()
+	if p := c.peak.Load(); p > 2 {
+		t.Fatalf("%d goroutines at once, want no more than 2", p)
+	}
+}
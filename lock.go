@@ -0,0 +1,10 @@
+package try
+
+import "sync"
+
+// SafeDo runs fn while holding mu. The lock is released even if fn panics; the panic is then re-raised.
+func SafeDo(mu sync.Locker, fn func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	fn()
+}
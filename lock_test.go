@@ -0,0 +1,30 @@
+package try_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+func TestSafeDo(t *testing.T) {
+	var mu sync.Mutex
+	ran := false
+	try.SafeDo(&mu, func() { ran = true })
+	if !ran {
+		t.Fatal("fn not called")
+	}
+
+	r := func() (r any) {
+		defer func() { r = recover() }()
+		try.SafeDo(&mu, func() { panic("boom") })
+		return nil
+	}()
+	if r != "boom" {
+		t.Fatalf("got %v, want the panic re-raised", r)
+	}
+	if !mu.TryLock() {
+		t.Fatal("lock held after a panic")
+	}
+	mu.Unlock()
+}
@@ -0,0 +1,47 @@
+package try
+
+import (
+	"log"
+	"sync/atomic"
+)
+
+var (
+	logger     atomic.Pointer[func(format string, args ...any)]
+	debugMode  atomic.Bool
+	strictSafe atomic.Bool
+)
+
+// SetDebug enables or disables debug mode, in which panics muted by Mute and MuteIf are logged.
+func SetDebug(enabled bool) {
+	debugMode.Store(enabled)
+}
+
+// SetStrictSafe enables or disables strict mode, in which SafeVal and its variants panic
+// instead of ignoring a non-nil error. It helps to find swallowed errors in tests.
+func SetStrictSafe(enabled bool) {
+	strictSafe.Store(enabled)
+}
+
+// SetLogger sets the function used to log panics that are recovered without an error to return them to,
+// e.g. by Catch(nil). A nil fn restores the default log.Printf.
+func SetLogger(fn func(format string, args ...any)) {
+	if fn == nil {
+		logger.Store(nil)
+		return
+	}
+	logger.Store(&fn)
+}
+
+func logf(format string, args ...any) {
+	if fn := logger.Load(); fn != nil {
+		(*fn)(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
+
+func logMuted(err error) {
+	if debugMode.Load() {
+		logf("Muted panic: %v", err)
+	}
+}
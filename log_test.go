@@ -0,0 +1,68 @@
+package try_test
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+func TestSetLogger(t *testing.T) {
+	logs := captureLog(t)
+	func() {
+		defer try.Catch(nil)
+		panic("boom")
+	}()
+	if len(*logs) != 1 || !strings.HasPrefix((*logs)[0], "Panic: boom") {
+		t.Fatalf("got %q, want the panic logged", *logs)
+	}
+
+	// A nil logger restores log.Printf.
+	try.SetLogger(nil)
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	func() {
+		defer try.Catch(nil)
+		panic("boom")
+	}()
+	if !strings.Contains(buf.String(), "Panic: boom") {
+		t.Fatalf("got %q, want the panic logged by log.Printf", buf.String())
+	}
+}
+
+func TestSetDebug(t *testing.T) {
+	logs := captureLog(t)
+	mute := func() {
+		defer try.Mute()
+		panic("boom")
+	}
+
+	mute()
+	if len(*logs) != 0 {
+		t.Fatalf("got %q, want Mute silent without debug mode", *logs)
+	}
+
+	try.SetDebug(true)
+	t.Cleanup(func() { try.SetDebug(false) })
+	mute()
+	if len(*logs) != 1 || (*logs)[0] != "Muted panic: boom" {
+		t.Fatalf("got %q, want the muted panic logged", *logs)
+	}
+}
+
+func panicUnhandled() {
+	defer try.Catch(nil)
+	panic("boom")
+}
+
+func TestCatchNilStack(t *testing.T) {
+	logs := captureLog(t)
+	panicUnhandled()
+	if len(*logs) != 1 || !strings.Contains((*logs)[0], "goroutine ") || !strings.Contains((*logs)[0], "try_test.panicUnhandled") {
+		t.Fatalf("got %q, want the stack of the panic logged", *logs)
+	}
+}
@@ -0,0 +1,30 @@
+package try
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// exit and stderr are variables to be replaceable for testing.
+var (
+	exit             = os.Exit
+	stderr io.Writer = os.Stderr
+)
+
+// Main runs fn safely. If fn panics, the error is printed to stderr and the program exits with code 1.
+//
+//	func main() {
+//		try.Main(run)
+//	}
+func Main(fn func()) {
+	MainExit(1, fn)
+}
+
+// MainExit is like Main, but exits with the given code.
+func MainExit(code int, fn func()) {
+	if err := Call(fn); err != nil {
+		fmt.Fprintln(stderr, err)
+		exit(code)
+	}
+}
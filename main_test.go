@@ -0,0 +1,31 @@
+package try_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+func TestMainExit(t *testing.T) {
+	var buf bytes.Buffer
+	code := -1
+	t.Cleanup(try.SetExit(func(c int) { code = c }, &buf))
+
+	try.Main(func() {})
+	if code != -1 || buf.Len() != 0 {
+		t.Fatalf("exited with %d, printed %q, want nothing on success", code, buf.String())
+	}
+
+	try.Main(func() { try.Check(errTest) })
+	if code != 1 || !strings.HasPrefix(buf.String(), "test error\n\t") || !strings.Contains(buf.String(), "main_test.go:") {
+		t.Fatalf("exited with %d, printed %q, want code 1 and the error with its location", code, buf.String())
+	}
+
+	buf.Reset()
+	try.MainExit(3, func() { panic("boom") })
+	if code != 3 || buf.String() != "boom\n" {
+		t.Fatalf("exited with %d, printed %q, want code 3 and the panic", code, buf.String())
+	}
+}
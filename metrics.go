@@ -0,0 +1,25 @@
+package try
+
+import "sync/atomic"
+
+var (
+	panicCount     atomic.Uint64
+	recoveredCount atomic.Uint64
+)
+
+// PanicCount returns the number of panics raised by the try helpers (Check, Val, Require, ...).
+func PanicCount() uint64 {
+	return panicCount.Load()
+}
+
+// RecoveredCount returns the number of panics recovered by Catch, Call, Go, Async and the other recovering helpers.
+// Panics which are re-raised are not counted.
+func RecoveredCount() uint64 {
+	return recoveredCount.Load()
+}
+
+// ResetCounters resets PanicCount and RecoveredCount to zero.
+func ResetCounters() {
+	panicCount.Store(0)
+	recoveredCount.Store(0)
+}
@@ -0,0 +1,42 @@
+package try_test
+
+import (
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+func TestCounters(t *testing.T) {
+	try.ResetCounters()
+	try.Call(func() { try.Check(errTest) })
+	try.Call(func() { panic("boom") })
+	func() {
+		defer func() { recover() }()
+		defer try.OnError(func(error) {}) // re-raised, not counted
+		try.Check(errTest)
+	}()
+	if got := try.PanicCount(); got != 2 {
+		t.Fatalf("got %d panics, want 2", got)
+	}
+	if got := try.RecoveredCount(); got != 2 {
+		t.Fatalf("got %d recovered panics, want 2", got)
+	}
+
+	try.ResetCounters()
+	func() {
+		defer try.CatchConvert(nil, func(any) error { return nil }) // swallowed, still counted
+		panic("boom")
+	}()
+	ch := make(chan int, 1)
+	try.SafeClose(ch)
+	try.SafeClose(ch)
+	try.SafeSend(ch, 1)
+	if got := try.RecoveredCount(); got != 3 {
+		t.Fatalf("got %d recovered panics, want 3", got)
+	}
+
+	try.ResetCounters()
+	if try.PanicCount() != 0 || try.RecoveredCount() != 0 {
+		t.Fatal("counters not reset")
+	}
+}
@@ -0,0 +1,67 @@
+package try
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// ErrorReport is a JSON-serializable representation of a recovered panic.
+type ErrorReport struct {
+	Message string    `json:"message"`
+	Type    string    `json:"type"`
+	Stack   []string  `json:"stack,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// Report returns the report of the value returned by recover(), or a zero report if there was no panic.
+// The stack is captured at recovery time unless the try helper which raised the panic captured it.
+//
+//	defer func() {
+//		if r := recover(); r != nil {
+//			json.NewEncoder(w).Encode(try.Report(r))
+//		}
+//	}()
+func Report(r any) ErrorReport {
+	if r == nil {
+		return ErrorReport{}
+	}
+	return newReport(r, toError(r), panicStack(r, 2))
+}
+
+// CatchReport recovers and sets the report of the panic by report pointer.
+// When report is nil the panic is logged.
+func CatchReport(report *ErrorReport) {
+	if r := recover(); r != nil {
+		stack := panicStack(r, 3)
+		err := handlePanic(r)
+		if report == nil { // log error
+			logf("Panic: %v\n%s", err, formatStack(stack))
+			return
+		}
+		*report = newReport(r, err, stack)
+	}
+}
+
+func newReport(r any, err error, stack []uintptr) ErrorReport {
+	origin := r // the original error of try helpers, not the internal wrappers
+	if e, ok := r.(*panicError); ok {
+		origin = e.err
+		if e, ok := origin.(*locatedError); ok {
+			origin = e.err
+		}
+	}
+	rep := ErrorReport{
+		Message: err.Error(),
+		Type:    fmt.Sprintf("%T", origin),
+		Time:    time.Now(),
+	}
+	frames := runtime.CallersFrames(stack)
+	for {
+		frame, more := frames.Next()
+		rep.Stack = append(rep.Stack, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+		if !more {
+			return rep
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package try_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goldic/try"
+)
+
+func TestReport(t *testing.T) {
+	if rep := try.Report(nil); rep.Message != "" || !rep.Time.IsZero() {
+		t.Fatalf("got %+v, want a zero report", rep)
+	}
+
+	var rep try.ErrorReport
+	func() {
+		defer func() { rep = try.Report(recover()) }()
+		try.Check(&validationError{field: "name"})
+	}()
+	if !strings.HasPrefix(rep.Message, "name is invalid") || rep.Type != "*try_test.validationError" {
+		t.Fatalf("got %+v, want the message and the type of the original error", rep)
+	}
+	if len(rep.Stack) == 0 || time.Since(rep.Time) > time.Minute {
+		t.Fatalf("got %+v, want the stack and the time", rep)
+	}
+	if _, err := json.Marshal(rep); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCatchReport(t *testing.T) {
+	var rep try.ErrorReport
+	func() {
+		defer try.CatchReport(&rep)
+		panic("boom")
+	}()
+	if rep.Message != "boom" || rep.Type != "string" {
+		t.Fatalf("got %+v, want the report of the panic", rep)
+	}
+
+	logs := captureLog(t)
+	func() {
+		defer try.CatchReport(nil)
+		panic("boom")
+	}()
+	if len(*logs) != 1 || !strings.HasPrefix((*logs)[0], "Panic: boom\n") {
+		t.Fatalf("got %q, want the panic logged", *logs)
+	}
+}
@@ -0,0 +1,46 @@
+package try
+
+// Result holds either a value or an error.
+type Result[T any] struct {
+	v   T
+	err error
+}
+
+// Ok returns a successful result with value v.
+func Ok[T any](v T) Result[T] {
+	return Result[T]{v: v}
+}
+
+// Err returns a failed result with error err.
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// Get returns the value or panics with the error like Val.
+func (r Result[T]) Get() T {
+	checkErr(r.err)
+	return r.v
+}
+
+// Unwrap returns the value and the error.
+func (r Result[T]) Unwrap() (T, error) {
+	return r.v, r.err
+}
+
+// StreamMap applies fn safely to each value received from in and sends a Result for each one.
+// The returned channel is closed when in is closed.
+func StreamMap[T, R any](in <-chan T, fn func(T) (R, error)) <-chan Result[R] {
+	out := make(chan Result[R])
+	go func() {
+		defer close(out)
+		for v := range in {
+			var r Result[R]
+			r.err = CallResult(func() (err error) {
+				r.v, err = fn(v)
+				return
+			})
+			out <- r
+		}
+	}()
+	return out
+}
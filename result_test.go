@@ -0,0 +1,61 @@
+package try_test
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+func TestResult(t *testing.T) {
+	r := try.Ok(42)
+	if v, err := r.Unwrap(); v != 42 || err != nil {
+		t.Fatalf("got %d, %v, want 42, nil", v, err)
+	}
+	var got int
+	if err := try.Call(func() { got = r.Get() }); err != nil || got != 42 {
+		t.Fatalf("got %d, %v, want 42, nil", got, err)
+	}
+
+	r = try.Err[int](errTest)
+	if v, err := r.Unwrap(); v != 0 || err != errTest {
+		t.Fatalf("got %d, %v, want 0, %v", v, err, errTest)
+	}
+	if err := try.Call(func() { r.Get() }); !errors.Is(err, errTest) {
+		t.Fatalf("got %v, want %v", err, errTest)
+	}
+}
+
+func TestStreamMap(t *testing.T) {
+	in := make(chan string)
+	go func() {
+		defer close(in)
+		for _, s := range []string{"1", "x", "3", "panic"} {
+			in <- s
+		}
+	}()
+	out := try.StreamMap(in, func(s string) (int, error) {
+		if s == "panic" {
+			panic("boom")
+		}
+		return strconv.Atoi(s)
+	})
+
+	var got []string
+	for r := range out {
+		v, err := r.Unwrap()
+		switch {
+		case errors.Is(err, try.ErrPanic):
+			got = append(got, "panic")
+		case err != nil:
+			got = append(got, "error")
+		default:
+			got = append(got, strconv.Itoa(v))
+		}
+	}
+	if want := []string{"1", "error", "3", "panic"}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
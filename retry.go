@@ -0,0 +1,64 @@
+package try
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Retry runs fn up to attempts times until it succeeds and returns the last error.
+// Panics in fn are recovered and treated as errors. fn is always run at least once.
+func Retry(attempts int, fn func() error) (err error) {
+	for i := 0; i < max(attempts, 1); i++ {
+		if err = CallResult(fn); err == nil {
+			return nil
+		}
+	}
+	return
+}
+
+// RetryIf runs fn up to attempts times like Retry, but returns an error immediately
+// if retryable reports it as permanent.
+func RetryIf(attempts int, retryable func(err error) bool, fn func() error) (err error) {
+	for i := 0; i < max(attempts, 1); i++ {
+		if err = CallResult(fn); err == nil || !retryable(err) {
+			return
+		}
+	}
+	return
+}
+
+// RetryBackoff runs fn up to attempts times until it succeeds, waiting base * 2^n between attempts.
+// It returns ctx.Err() as soon as the context is cancelled, otherwise the last error.
+func RetryBackoff(ctx context.Context, attempts int, base time.Duration, fn func() error) (err error) {
+	for i := 0; i < max(attempts, 1); i++ {
+		if i > 0 {
+			timer := time.NewTimer(backoff(base, i-1))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+		if e := ctx.Err(); e != nil {
+			return e
+		}
+		if err = CallResult(fn); err == nil {
+			return nil
+		}
+	}
+	return
+}
+
+// backoff returns base * 2^n, capped to the maximum duration.
+func backoff(base time.Duration, n int) time.Duration {
+	d := base
+	for ; n > 0 && d > 0; n-- {
+		if d > math.MaxInt64/2 {
+			return math.MaxInt64
+		}
+		d *= 2
+	}
+	return d
+}
@@ -0,0 +1,98 @@
+package try_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/goldic/try"
+)
+
+func TestRetry(t *testing.T) {
+	calls := 0
+	if err := try.Retry(3, func() error { calls++; return nil }); err != nil || calls != 1 {
+		t.Fatalf("got %v after %d calls, want success on the first try", err, calls)
+	}
+
+	calls = 0
+	err := try.Retry(3, func() error {
+		if calls++; calls < 3 {
+			try.Check(errTest)
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Fatalf("got %v after %d calls, want success after 3", err, calls)
+	}
+
+	calls = 0
+	err = try.Retry(3, func() error {
+		if calls++; calls == 3 {
+			panic("last")
+		}
+		return errTest
+	})
+	if !errors.Is(err, try.ErrPanic) || calls != 3 {
+		t.Fatalf("got %v after %d calls, want the panic of the last attempt", err, calls)
+	}
+
+	calls = 0
+	if err := try.Retry(0, func() error { calls++; return errTest }); err != errTest || calls != 1 {
+		t.Fatalf("got %v after %d calls, want one attempt", err, calls)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	var starts []time.Time
+	err := try.RetryBackoff(context.Background(), 3, 10*time.Millisecond, func() error {
+		starts = append(starts, time.Now())
+		return errTest
+	})
+	if err != errTest || len(starts) != 3 {
+		t.Fatalf("got %v after %d attempts, want the last error after 3", err, len(starts))
+	}
+	if d := starts[2].Sub(starts[1]); d < 20*time.Millisecond {
+		t.Fatalf("second delay is %v, want at least 20ms", d)
+	}
+}
+
+func TestRetryBackoffCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	calls := 0
+	start := time.Now()
+	err := try.RetryBackoff(ctx, 1000, time.Duration(math.MaxInt64/4), func() error {
+		calls++
+		panic("boom")
+	})
+	if !errors.Is(err, context.DeadlineExceeded) || calls != 1 {
+		t.Fatalf("got %v after %d calls, want the context error after 1", err, calls)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Fatalf("returned after %v, want promptly after the deadline", d)
+	}
+}
+
+func TestRetryIf(t *testing.T) {
+	errPermanent := errors.New("permanent")
+	retryable := func(err error) bool { return !errors.Is(err, errPermanent) }
+
+	calls := 0
+	err := try.RetryIf(5, retryable, func() error {
+		if calls++; calls == 2 {
+			try.Check(errPermanent)
+		}
+		return errTest
+	})
+	if !errors.Is(err, errPermanent) || calls != 2 {
+		t.Fatalf("got %v after %d calls, want the permanent error after 2", err, calls)
+	}
+
+	calls = 0
+	err = try.RetryIf(3, retryable, func() error { calls++; return errTest })
+	if err != errTest || calls != 3 {
+		t.Fatalf("got %v after %d calls, want %v after all 3", err, calls, errTest)
+	}
+}
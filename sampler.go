@@ -0,0 +1,43 @@
+package try
+
+import "sync"
+
+// Sampler records the most recent panics muted by MuteSampled in a ring buffer.
+type Sampler struct {
+	mx   sync.Mutex
+	buf  []error
+	next int
+	full bool
+}
+
+// NewSampler returns a Sampler which retains up to n recent panics.
+func NewSampler(n int) *Sampler {
+	return &Sampler{buf: make([]error, max(n, 1))}
+}
+
+// MuteSampled mutes panic-error like Mute and records it in the sampler.
+func (s *Sampler) MuteSampled() {
+	if r := recover(); r != nil {
+		s.add(handlePanic(r))
+	}
+}
+
+// RecentDrops returns the recorded panic-errors, oldest first.
+func (s *Sampler) RecentDrops() []error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if !s.full {
+		return append([]error(nil), s.buf[:s.next]...)
+	}
+	return append(append([]error(nil), s.buf[s.next:]...), s.buf[:s.next]...)
+}
+
+func (s *Sampler) add(err error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.buf[s.next] = err
+	s.next++
+	if s.next == len(s.buf) {
+		s.next, s.full = 0, true
+	}
+}
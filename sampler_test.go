@@ -0,0 +1,25 @@
+package try_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+func TestSampler(t *testing.T) {
+	s := try.NewSampler(3)
+	if drops := s.RecentDrops(); len(drops) != 0 {
+		t.Fatalf("got %v, want no drops", drops)
+	}
+	for i := range 5 {
+		func() {
+			defer s.MuteSampled()
+			panic(i)
+		}()
+	}
+	drops := s.RecentDrops()
+	if got := fmt.Sprint(drops); got != "[2 3 4]" {
+		t.Fatalf("got %s, want the 3 most recent drops, oldest first", got)
+	}
+}
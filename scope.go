@@ -0,0 +1,49 @@
+package try
+
+// Scope collects rollback actions which run only if a panic or an error leaves the scope.
+//
+//	s := try.NewScope()
+//	defer s.Finish(&err)
+type Scope struct {
+	rollbacks []func()
+}
+
+// NewScope returns a new empty scope.
+func NewScope() *Scope {
+	return &Scope{}
+}
+
+// OnFailure registers fn to run if the scope fails.
+func (s *Scope) OnFailure(fn func()) {
+	s.rollbacks = append(s.rollbacks, fn)
+}
+
+// Finish recovers and sets error by err pointer. If a panic was recovered or *err is not nil,
+// the rollbacks run in reverse order; otherwise they are discarded.
+// Panics of the rollbacks are recovered and logged.
+func (s *Scope) Finish(err *error) {
+	r := recover()
+	if r != nil || (err != nil && *err != nil) {
+		for i := len(s.rollbacks) - 1; i >= 0; i-- {
+			if e := Call(s.rollbacks[i]); e != nil { // a failing rollback doesn't stop the others
+				logf("Rollback: %v", e)
+			}
+		}
+	}
+	s.rollbacks = nil
+	if r != nil {
+		catch(err, r)
+	}
+}
+
+// ValWithCleanup returns a function like Val, which also registers cleanup of the returned value in scope s.
+// The cleanup only runs if the scope fails later:
+//
+//	f := try.ValWithCleanup(s, closeFile)(os.Open(path))
+func ValWithCleanup[T any](s *Scope, cleanup func(T)) func(v T, err error) T {
+	return func(v T, err error) T {
+		checkErr(err)
+		s.OnFailure(func() { cleanup(v) })
+		return v
+	}
+}
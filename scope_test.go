@@ -0,0 +1,81 @@
+package try_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+func TestValWithCleanup(t *testing.T) {
+	var released []string
+	release := func(name string) { released = append(released, name) }
+	open := func(name string) (string, error) { return name, nil }
+	run := func(fail error) (err error) {
+		s := try.NewScope()
+		defer s.Finish(&err)
+		try.ValWithCleanup(s, release)(open("a"))
+		try.ValWithCleanup(s, release)(open("b"))
+		try.Check(fail)
+		return nil
+	}
+
+	if err := run(nil); err != nil || len(released) != 0 {
+		t.Fatalf("got %v, released %v, want nothing released on success", err, released)
+	}
+	err := run(errTest)
+	if !errors.Is(err, errTest) || !slices.Equal(released, []string{"b", "a"}) {
+		t.Fatalf("got %v, released %v, want both released in reverse order", err, released)
+	}
+
+	// A failed acquisition has nothing to clean up.
+	released = nil
+	err = func() (err error) {
+		s := try.NewScope()
+		defer s.Finish(&err)
+		try.ValWithCleanup(s, release)("c", errTest)
+		return nil
+	}()
+	if !errors.Is(err, errTest) || len(released) != 0 {
+		t.Fatalf("got %v, released %v, want nothing released", err, released)
+	}
+}
+
+func TestScope(t *testing.T) {
+	var rolledBack []int
+	run := func(fail func() error) (err error) {
+		s := try.NewScope()
+		defer s.Finish(&err)
+		for i := range 3 {
+			s.OnFailure(func() { rolledBack = append(rolledBack, i) })
+		}
+		return fail()
+	}
+
+	if err := run(func() error { return nil }); err != nil || len(rolledBack) != 0 {
+		t.Fatalf("got %v, rolled back %v, want no rollbacks on success", err, rolledBack)
+	}
+	if err := run(func() error { return errTest }); err != errTest || !slices.Equal(rolledBack, []int{2, 1, 0}) {
+		t.Fatalf("got %v, rolled back %v, want rollbacks for a returned error", err, rolledBack)
+	}
+	rolledBack = nil
+	if err := run(func() error { panic("boom") }); !errors.Is(err, try.ErrPanic) || !slices.Equal(rolledBack, []int{2, 1, 0}) {
+		t.Fatalf("got %v, rolled back %v, want rollbacks for a panic", err, rolledBack)
+	}
+
+	// A failing rollback is logged and doesn't stop the others.
+	logs := captureLog(t)
+	rolledBack = nil
+	func() (err error) {
+		s := try.NewScope()
+		defer s.Finish(&err)
+		s.OnFailure(func() { rolledBack = append(rolledBack, 0) })
+		s.OnFailure(func() { panic("rollback failed") })
+		return errTest
+	}()
+	if len(rolledBack) != 1 || len(*logs) != 1 || !strings.HasPrefix((*logs)[0], "Rollback: rollback failed") {
+		t.Fatalf("rolled back %v, logged %q", rolledBack, *logs)
+	}
+}
@@ -0,0 +1,48 @@
+//go:build go1.23
+
+package try
+
+import "iter"
+
+// SafeSeq returns an iterator over seq, which stops when seq panics and sets the recovered error by err pointer.
+// Panics of the loop body are not recovered.
+func SafeSeq[T any](seq iter.Seq[T], err *error) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		inBody := false
+		defer func() {
+			if inBody { // panic of the loop body
+				return
+			}
+			if r := recover(); r != nil {
+				catch(err, r)
+			}
+		}()
+		seq(func(v T) bool {
+			inBody = true
+			ok := yield(v)
+			inBody = false
+			return ok
+		})
+	}
+}
+
+// SafeSeq2 is like SafeSeq for key-value iterators.
+func SafeSeq2[K, V any](seq iter.Seq2[K, V], err *error) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		inBody := false
+		defer func() {
+			if inBody { // panic of the loop body
+				return
+			}
+			if r := recover(); r != nil {
+				catch(err, r)
+			}
+		}()
+		seq(func(k K, v V) bool {
+			inBody = true
+			ok := yield(k, v)
+			inBody = false
+			return ok
+		})
+	}
+}
@@ -0,0 +1,66 @@
+//go:build go1.23
+
+package try_test
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+// numbers yields 1..n and panics after n if fail.
+func numbers(n int, fail bool) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 1; i <= n; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+		if fail {
+			panic("producer failed")
+		}
+	}
+}
+
+func TestSafeSeq(t *testing.T) {
+	var err error
+	got := slices.Collect(try.SafeSeq(numbers(3, false), &err))
+	if err != nil || !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("got %v, %v", got, err)
+	}
+
+	got = slices.Collect(try.SafeSeq(numbers(2, true), &err))
+	if !errors.Is(err, try.ErrPanic) || !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("got %v, %v, want the values before the panic and the panic-error", got, err)
+	}
+
+	err = nil
+	r := func() (r any) {
+		defer func() { r = recover() }()
+		for range try.SafeSeq(numbers(3, false), &err) {
+			panic("body failed")
+		}
+		return nil
+	}()
+	if r != "body failed" || err != nil {
+		t.Fatalf("got %v, %v, want the panic of the loop body propagated", r, err)
+	}
+}
+
+func TestSafeSeq2(t *testing.T) {
+	var err error
+	seq := try.SafeSeq2(func(yield func(int, string) bool) {
+		yield(0, "a")
+		panic("producer failed")
+	}, &err)
+	var got []string
+	for _, v := range seq {
+		got = append(got, v)
+	}
+	if !errors.Is(err, try.ErrPanic) || !slices.Equal(got, []string{"a"}) {
+		t.Fatalf("got %v, %v", got, err)
+	}
+}
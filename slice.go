@@ -0,0 +1,98 @@
+package try
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Map returns the results of fn applied to each element of in, or panics on the first error.
+func Map[T, R any](in []T, fn func(T) (R, error)) []R {
+	out := make([]R, len(in))
+	for i, v := range in {
+		r, err := fn(v)
+		if err != nil {
+			checkErr(indexError(i, err))
+		}
+		out[i] = r
+	}
+	return out
+}
+
+// ValEach returns the values of fn called for each index from 0 to n-1, or panics on the first error.
+func ValEach[T any](n int, fn func(i int) (T, error)) []T {
+	out := make([]T, n)
+	for i := range out {
+		v, err := fn(i)
+		if err != nil {
+			checkErr(indexError(i, err))
+		}
+		out[i] = v
+	}
+	return out
+}
+
+// Each calls fn for each element of in and panics on the first error.
+func Each[T any](in []T, fn func(T) error) {
+	for i, v := range in {
+		if err := fn(v); err != nil {
+			checkErr(indexError(i, err))
+		}
+	}
+}
+
+// Filter returns the elements of in for which pred returns true, or panics on the first error.
+func Filter[T any](in []T, pred func(T) (bool, error)) []T {
+	out := make([]T, 0, len(in))
+	for i, v := range in {
+		ok, err := pred(v)
+		if err != nil {
+			checkErr(indexError(i, err))
+		}
+		if ok {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Reduce folds the elements of in into an accumulator starting with init, or panics on the first error.
+func Reduce[T, A any](in []T, init A, fn func(A, T) (A, error)) A {
+	acc := init
+	for i, v := range in {
+		var err error
+		if acc, err = fn(acc, v); err != nil {
+			checkErr(indexError(i, err))
+		}
+	}
+	return acc
+}
+
+// Collect calls all functions and returns the values of the successful calls and the joined error of the failed ones.
+// Panics in the functions are recovered and treated as errors.
+func Collect[T any](fns ...func() (T, error)) (values []T, err error) {
+	var errs []error
+	for _, fn := range fns {
+		var v T
+		if e := CallResult(func() (err error) {
+			v, err = fn()
+			return
+		}); e != nil {
+			errs = append(errs, e)
+			continue
+		}
+		values = append(values, v)
+	}
+	return values, joinSlice(errs)
+}
+
+func indexError(i int, err error) error {
+	return fmt.Errorf("index %d: %w", i, err)
+}
+
+// SafeSort sorts s by less and returns the recovered panic-error of less.
+// A panic aborts the sort, leaving s in a partially sorted order.
+func SafeSort[T any](s []T, less func(a, b T) bool) error {
+	return Call(func() {
+		sort.Slice(s, func(i, j int) bool { return less(s[i], s[j]) })
+	})
+}
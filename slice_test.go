@@ -0,0 +1,147 @@
+package try_test
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+func TestMap(t *testing.T) {
+	var got []int
+	err := try.Call(func() { got = try.Map([]string{"1", "2", "3"}, strconv.Atoi) })
+	if err != nil || !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("got %v, %v", got, err)
+	}
+
+	err = try.Call(func() { try.Map([]string{"1", "x", "3"}, strconv.Atoi) })
+	if !errors.Is(err, strconv.ErrSyntax) || !strings.HasPrefix(err.Error(), "index 1: ") {
+		t.Fatalf("got %v, want the error of index 1", err)
+	}
+}
+
+func TestEach(t *testing.T) {
+	var visited []int
+	visit := func(v int) error {
+		visited = append(visited, v)
+		if v == 2 {
+			return errTest
+		}
+		return nil
+	}
+	err := try.Call(func() { try.Each([]int{1, 2, 3}, visit) })
+	if !errors.Is(err, errTest) || !strings.HasPrefix(err.Error(), "index 1: ") {
+		t.Fatalf("got %v, want the error of index 1", err)
+	}
+	if !slices.Equal(visited, []int{1, 2}) {
+		t.Fatalf("visited %v, want the loop stopped after the failure", visited)
+	}
+	if err := try.Call(func() { try.Each(nil, visit) }); err != nil {
+		t.Fatalf("unexpected error for an empty slice: %v", err)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	errB := errors.New("b")
+	values, err := try.Collect(
+		func() (int, error) { return 1, nil },
+		func() (int, error) { return 0, errTest },
+		func() (int, error) { return 3, nil },
+		func() (int, error) { panic(errB) },
+	)
+	if !slices.Equal(values, []int{1, 3}) {
+		t.Fatalf("got %v, want the values of the successful calls", values)
+	}
+	if !errors.Is(err, errTest) || !errors.Is(err, errB) {
+		t.Fatalf("got %v, want both errors joined", err)
+	}
+
+	values, err = try.Collect(func() (int, error) { return 1, nil })
+	if err != nil || !slices.Equal(values, []int{1}) {
+		t.Fatalf("got %v, %v", values, err)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	even := func(v int) (bool, error) {
+		if v < 0 {
+			return false, errTest
+		}
+		return v%2 == 0, nil
+	}
+	var got []int
+	err := try.Call(func() { got = try.Filter([]int{1, 2, 3, 4}, even) })
+	if err != nil || !slices.Equal(got, []int{2, 4}) {
+		t.Fatalf("got %v, %v", got, err)
+	}
+
+	err = try.Call(func() { try.Filter([]int{2, -1, 4}, even) })
+	if !errors.Is(err, errTest) || !strings.HasPrefix(err.Error(), "index 1: ") {
+		t.Fatalf("got %v, want the error of index 1", err)
+	}
+
+	if got := try.Filter(nil, even); got == nil || len(got) != 0 {
+		t.Fatalf("got %#v, want an empty non-nil slice", got)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	sum := func(acc int, s string) (int, error) {
+		v, err := strconv.Atoi(s)
+		return acc + v, err
+	}
+	var got int
+	err := try.Call(func() { got = try.Reduce([]string{"1", "2", "3"}, 10, sum) })
+	if err != nil || got != 16 {
+		t.Fatalf("got %d, %v, want 16, nil", got, err)
+	}
+
+	err = try.Call(func() { try.Reduce([]string{"1", "2", "x"}, 0, sum) })
+	if !errors.Is(err, strconv.ErrSyntax) || !strings.HasPrefix(err.Error(), "index 2: ") {
+		t.Fatalf("got %v, want the error of index 2", err)
+	}
+
+	if got := try.Reduce(nil, 5, sum); got != 5 {
+		t.Fatalf("got %d, want the initial value for an empty slice", got)
+	}
+}
+
+func TestValEach(t *testing.T) {
+	in := []string{"1", "2", "3"}
+	var got []int
+	err := try.Call(func() { got = try.ValEach(len(in), func(i int) (int, error) { return strconv.Atoi(in[i]) }) })
+	if err != nil || !slices.Equal(got, []int{1, 2, 3}) {
+		t.Fatalf("got %v, %v", got, err)
+	}
+
+	in[1] = "x"
+	err = try.Call(func() { try.ValEach(len(in), func(i int) (int, error) { return strconv.Atoi(in[i]) }) })
+	if !errors.Is(err, strconv.ErrSyntax) || !strings.HasPrefix(err.Error(), "index 1: ") {
+		t.Fatalf("got %v, want the error of index 1", err)
+	}
+}
+
+func TestSafeSort(t *testing.T) {
+	s := []int{3, 1, 2}
+	if err := try.SafeSort(s, func(a, b int) bool { return a < b }); err != nil || !slices.Equal(s, []int{1, 2, 3}) {
+		t.Fatalf("got %v, %v", s, err)
+	}
+
+	s = []int{3, 1, 2}
+	err := try.SafeSort(s, func(a, b int) bool {
+		if a == 2 || b == 2 {
+			try.Check(errTest)
+		}
+		return a < b
+	})
+	if !errors.Is(err, errTest) {
+		t.Fatalf("got %v, want %v", err, errTest)
+	}
+	slices.Sort(s)
+	if !slices.Equal(s, []int{1, 2, 3}) {
+		t.Fatalf("got %v, want the elements kept", s)
+	}
+}
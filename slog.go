@@ -0,0 +1,18 @@
+package try
+
+import "log/slog"
+
+// CatchSlog recovers, logs the panic with its stack at error level and sets error by err pointer.
+// When err is nil the panic is only logged. A nil logger means slog.Default().
+func CatchSlog(logger *slog.Logger, err *error) {
+	if r := recover(); r != nil {
+		if logger == nil {
+			logger = slog.Default()
+		}
+		e := handlePanic(r)
+		logger.Error("panic recovered", "error", e, "stack", formatStack(panicStack(r, 3)))
+		if err != nil {
+			*err = joinErrors(*err, e)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package try_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+// recordHandler is a slog.Handler which records the emitted records.
+type recordHandler struct {
+	records []slog.Record
+}
+
+func (h *recordHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *recordHandler) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *recordHandler) WithGroup(string) slog.Handler            { return h }
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	h.records = append(h.records, r)
+	return nil
+}
+
+func attrs(r slog.Record) map[string]slog.Value {
+	m := map[string]slog.Value{}
+	r.Attrs(func(a slog.Attr) bool {
+		m[a.Key] = a.Value
+		return true
+	})
+	return m
+}
+
+func TestCatchSlog(t *testing.T) {
+	for _, withErr := range []bool{true, false} {
+		h := &recordHandler{}
+		var err error
+		func() {
+			if withErr {
+				defer try.CatchSlog(slog.New(h), &err)
+			} else {
+				defer try.CatchSlog(slog.New(h), nil)
+			}
+			try.Check(errTest)
+		}()
+		if withErr && !errors.Is(err, errTest) {
+			t.Fatalf("got %v, want %v", err, errTest)
+		}
+		if len(h.records) != 1 || h.records[0].Level != slog.LevelError {
+			t.Fatalf("got %d records, want one at error level", len(h.records))
+		}
+		a := attrs(h.records[0])
+		if e, ok := a["error"].Any().(error); !ok || !errors.Is(e, errTest) {
+			t.Fatalf("got error attribute %v, want %v", a["error"], errTest)
+		}
+		if stack := a["stack"]; stack.Kind() != slog.KindString || !strings.Contains(stack.String(), "TestCatchSlog") {
+			t.Fatalf("got stack attribute %v, want the stack of the panic", stack)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package try
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+const maxStackDepth = 64
+
+var captureStack atomic.Bool
+
+// SetCaptureStack enables or disables capturing of the full call stack when try helpers panic.
+// Capturing is disabled by default, so only the caller location is attached to the error.
+func SetCaptureStack(enabled bool) {
+	captureStack.Store(enabled)
+}
+
+// locationFormat attaches the caller location to errors raised by try helpers, DefaultLocationFormat by default.
+var locationFormat atomic.Pointer[func(err error, file string, line int) error]
+
+// SetLocationFormat sets the function which attaches the caller location to errors raised by try helpers.
+// By default the location is appended to the error message on a separate line.
+// A nil fn disables capturing of the location altogether, which makes raising errors cheaper;
+// SetLocationFormat(DefaultLocationFormat) restores the default.
+// It is meant to be called once at startup, not to be changed per call.
+func SetLocationFormat(fn func(err error, file string, line int) error) {
+	locationFormat.Store(&fn)
+}
+
+// DefaultLocationFormat is the default location format: it appends the location to the error message on a separate line.
+func DefaultLocationFormat(err error, file string, line int) error {
+	return &locatedError{err: err, file: file, line: line}
+}
+
+// Stack returns the call stack captured when err was raised, or nil if no stack was captured.
+// Use runtime.CallersFrames to resolve it.
+func Stack(err error) []uintptr {
+	var e *panicError
+	if errors.As(err, &e) {
+		return e.stack
+	}
+	return nil
+}
+
+// CatchStack recovers, sets error by err pointer and sets the call stack of the panic by stack pointer.
+// The stack captured by the try helper is used when available, otherwise it's captured at recovery time.
+func CatchStack(err *error, stack *[]uintptr) {
+	if r := recover(); r != nil {
+		if stack != nil {
+			*stack = panicStack(r, 3)
+		}
+		catch(err, r)
+	}
+}
+
+// panicStack returns the stack captured by the try helper which raised r,
+// or the current stack without skip frames.
+func panicStack(r any, skip int) []uintptr {
+	if e, ok := r.(*panicError); ok && e.stack != nil {
+		return e.stack
+	}
+	return callers(skip + 1)
+}
+
+func formatStack(pcs []uintptr) string {
+	var b strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			return b.String()
+		}
+	}
+}
+
+func callers(skip int) []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip+1, pcs)
+	return pcs[:n]
+}
+
+// externalCallers returns the current stack starting at the first caller outside the try package.
+func externalCallers() []uintptr {
+	pcs := callers(2)
+	for i := range pcs {
+		frames := runtime.CallersFrames(pcs[i : i+1])
+		for {
+			frame, more := frames.Next()
+			if !more {
+				if !strings.HasPrefix(frame.Function, pkgPrefix) {
+					return pcs[i:]
+				}
+				break
+			}
+		}
+	}
+	return pcs
+}
@@ -0,0 +1,96 @@
+package try_test
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+// hasFunc reports whether the stack contains a frame of a function whose name ends with name.
+func hasFunc(stack []uintptr, name string) bool {
+	frames := runtime.CallersFrames(stack)
+	for {
+		frame, more := frames.Next()
+		if strings.HasSuffix(frame.Function, name) {
+			return true
+		}
+		if !more {
+			return false
+		}
+	}
+}
+
+func raiseNested() { try.Check(errTest) }
+
+func TestCaptureStack(t *testing.T) {
+	if err := try.Call(raiseNested); try.Stack(err) != nil {
+		t.Fatal("stack captured without SetCaptureStack")
+	}
+
+	try.SetCaptureStack(true)
+	t.Cleanup(func() { try.SetCaptureStack(false) })
+	err := try.Call(raiseNested)
+	stack := try.Stack(err)
+	if !hasFunc(stack, "raiseNested") || !hasFunc(stack, "TestCaptureStack") {
+		t.Fatal("stack doesn't contain the callers")
+	}
+	if hasFunc(stack, "try.Check") {
+		t.Fatal("stack contains frames of the try package")
+	}
+	if n := testing.AllocsPerRun(100, func() { try.Check(nil) }); n != 0 {
+		t.Fatalf("got %v allocs on the happy path, want 0", n)
+	}
+}
+
+func TestCatchStack(t *testing.T) {
+	var stack []uintptr
+	err := func() (err error) {
+		defer try.CatchStack(&err, &stack)
+		var p *struct{ x int }
+		_ = p.x // runtime panic, no stack captured by try
+		return nil
+	}()
+	if err == nil || !hasFunc(stack, "TestCatchStack.func1") {
+		t.Fatalf("got %v and a stack without the panicking function", err)
+	}
+	if errors.Is(err, try.ErrTry) {
+		t.Fatalf("runtime panic reported as a try error: %v", err)
+	}
+}
+
+func TestSetLocationFormat(t *testing.T) {
+	t.Cleanup(func() { try.SetLocationFormat(try.DefaultLocationFormat) })
+
+	try.SetLocationFormat(func(err error, file string, line int) error {
+		return fmt.Errorf("%s:%d: %w", filepath.Base(file), line, err)
+	})
+	_, _, line, _ := runtime.Caller(0)
+	err := try.Call(func() { try.Check(errTest) }) // line+1
+	if want := fmt.Sprintf("stack_test.go:%d: test error", line+1); err.Error() != want || !errors.Is(err, errTest) {
+		t.Fatalf("got %q, want %q", err.Error(), want)
+	}
+	if _, got, ok := try.Location(err); !ok || got != line+1 {
+		t.Fatalf("got line %d, %t, want the location still reported", got, ok)
+	}
+
+	try.SetLocationFormat(nil)
+	err = try.Call(func() { try.Check(errTest) })
+	if err.Error() != "test error" || !errors.Is(err, errTest) {
+		t.Fatalf("got %q, want the error without a location", err.Error())
+	}
+	if _, _, ok := try.Location(err); ok {
+		t.Fatal("location reported with the location disabled")
+	}
+
+	try.SetLocationFormat(try.DefaultLocationFormat)
+	_, _, line, _ = runtime.Caller(0)
+	err = try.Call(func() { try.Check(errTest) }) // line+1
+	if want := fmt.Sprintf("stack_test.go:%d", line+1); !strings.HasPrefix(err.Error(), "test error\n\t") || !strings.HasSuffix(err.Error(), want) {
+		t.Fatalf("got %q, want the default location restored", err.Error())
+	}
+}
@@ -0,0 +1,127 @@
+package try
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Supervisor runs a function in a goroutine and restarts it when it panics.
+type Supervisor struct {
+	done     chan struct{}
+	mx       sync.Mutex
+	restarts int
+	err      error
+}
+
+// Supervise runs fn in a goroutine and restarts it whenever it panics, until fn returns normally or ctx is done.
+// Restarts are logged via the configured logger.
+func Supervise(ctx context.Context, fn func()) *Supervisor {
+	return SuperviseWith(ctx, SuperviseConfig{}, fn)
+}
+
+// SuperviseBackoff runs fn like Supervise, but waits base * 2^n before the n-th restart, up to 1 minute.
+func SuperviseBackoff(ctx context.Context, base time.Duration, fn func()) *Supervisor {
+	return SuperviseWith(ctx, SuperviseConfig{Backoff: base}, fn)
+}
+
+// SuperviseConfig configures SuperviseWith.
+type SuperviseConfig struct {
+	// MaxRestarts is the max number of restarts within Window; no limit if < 1.
+	MaxRestarts int
+	// Window is the period in which restarts are counted; all restarts are counted if 0.
+	Window time.Duration
+	// Backoff is the base delay before restarts, doubled with each restart.
+	Backoff time.Duration
+	// MaxBackoff is the max delay before a restart, 1 minute by default. The delay starts from Backoff again
+	// once fn has run for longer than MaxBackoff without panicking.
+	MaxBackoff time.Duration
+	// OnGiveUp is called with the last error when the supervisor stops because of too many restarts.
+	OnGiveUp func(err error)
+}
+
+// SuperviseWith runs fn like Supervise and gives up when fn panics more than cfg.MaxRestarts times within cfg.Window.
+func SuperviseWith(ctx context.Context, cfg SuperviseConfig, fn func()) *Supervisor {
+	s := &Supervisor{done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		s.run(ctx, cfg, fn)
+	}()
+	return s
+}
+
+// Done returns a channel which is closed when the supervisor stops.
+func (s *Supervisor) Done() <-chan struct{} {
+	return s.done
+}
+
+// Restarts returns the number of restarts so far.
+func (s *Supervisor) Restarts() int {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.restarts
+}
+
+// Err returns the last recovered panic-error or nil.
+func (s *Supervisor) Err() error {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.err
+}
+
+// defaultMaxBackoff is the max delay before a restart if SuperviseConfig.MaxBackoff is not set.
+const defaultMaxBackoff = time.Minute
+
+func (s *Supervisor) run(ctx context.Context, cfg SuperviseConfig, fn func()) {
+	maxBackoff := cfg.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = max(defaultMaxBackoff, cfg.Backoff)
+	}
+	var restarts []time.Time // within the window
+	attempt := 0             // restarts since the last healthy run
+	for n := 0; ctx.Err() == nil; n++ {
+		start := time.Now()
+		err := Call(fn)
+		if err == nil {
+			return
+		}
+		if time.Since(start) > maxBackoff {
+			attempt = 0
+		}
+		s.mx.Lock()
+		s.err = err
+		s.mx.Unlock()
+		if cfg.MaxRestarts > 0 {
+			now := time.Now()
+			if cfg.Window > 0 {
+				restarts = slices.DeleteFunc(restarts, func(t time.Time) bool { return now.Sub(t) > cfg.Window })
+			}
+			if len(restarts) >= cfg.MaxRestarts {
+				logf("Supervise: giving up after %d restarts, last panic: %v", n, err)
+				if cfg.OnGiveUp != nil {
+					cfg.OnGiveUp(err)
+				}
+				return
+			}
+			restarts = append(restarts, now)
+		}
+		if cfg.Backoff > 0 {
+			timer := time.NewTimer(min(backoff(cfg.Backoff, attempt), maxBackoff))
+			attempt++
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		s.mx.Lock()
+		s.restarts++
+		s.mx.Unlock()
+		logf("Supervise: restart %d after panic: %v", n+1, err)
+	}
+}
@@ -0,0 +1,124 @@
+package try_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goldic/try"
+)
+
+func TestSupervise(t *testing.T) {
+	logs := captureLog(t)
+	runs := 0
+	s := try.Supervise(context.Background(), func() {
+		runs++
+		if runs < 3 {
+			try.Check(errTest)
+		}
+	})
+	<-s.Done()
+	if runs != 3 || s.Restarts() != 2 {
+		t.Fatalf("got %d runs, %d restarts, want 3 and 2", runs, s.Restarts())
+	}
+	if !errors.Is(s.Err(), errTest) {
+		t.Fatalf("got %v, want %v", s.Err(), errTest)
+	}
+	if len(*logs) != 2 {
+		t.Fatalf("got %d log lines, want 2: %q", len(*logs), *logs)
+	}
+}
+
+func TestSuperviseCancel(t *testing.T) {
+	captureLog(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	s := try.SuperviseBackoff(ctx, time.Hour, func() { panic("boom") })
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	select {
+	case <-s.Done():
+	case <-time.After(time.Second):
+		t.Fatal("supervisor didn't stop")
+	}
+	if s.Restarts() != 0 {
+		t.Fatalf("got %d restarts, want 0", s.Restarts())
+	}
+}
+
+func TestSuperviseMaxBackoff(t *testing.T) {
+	captureLog(t)
+	runs := 0
+	start := time.Now()
+	s := try.SuperviseWith(context.Background(), try.SuperviseConfig{
+		Backoff:    time.Millisecond,
+		MaxBackoff: 5 * time.Millisecond,
+	}, func() {
+		if runs++; runs <= 10 {
+			panic("boom")
+		}
+	})
+	<-s.Done()
+	// The uncapped delays would add up to more than a second.
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Fatalf("restarts took %v, want them capped", d)
+	}
+}
+
+func TestSuperviseBackoffReset(t *testing.T) {
+	captureLog(t)
+	const base, maxDelay = 20 * time.Millisecond, 200 * time.Millisecond
+	var starts []time.Time
+	s := try.SuperviseWith(context.Background(), try.SuperviseConfig{Backoff: base, MaxBackoff: maxDelay}, func() {
+		starts = append(starts, time.Now())
+		switch len(starts) {
+		case 1, 2, 3, 4: // crash loop: 20, 40, 80, 160ms
+			panic("boom")
+		case 5: // healthy run
+			time.Sleep(maxDelay + 50*time.Millisecond)
+			panic("boom")
+		}
+	})
+	<-s.Done()
+	if len(starts) != 6 {
+		t.Fatalf("got %d runs, want 6", len(starts))
+	}
+	healthyEnd := starts[4].Add(maxDelay + 50*time.Millisecond)
+	if d := starts[5].Sub(healthyEnd); d > maxDelay/2 {
+		t.Fatalf("delay after a healthy run is %v, want about %v", d, base)
+	}
+}
+
+func TestSuperviseMaxRestarts(t *testing.T) {
+	captureLog(t)
+	runs := 0
+	var gaveUp error
+	s := try.SuperviseWith(context.Background(), try.SuperviseConfig{
+		MaxRestarts: 3,
+		Window:      time.Minute,
+		OnGiveUp:    func(err error) { gaveUp = err },
+	}, func() {
+		runs++
+		panic(errTest)
+	})
+	<-s.Done()
+	if runs != 4 || s.Restarts() != 3 {
+		t.Fatalf("got %d runs, %d restarts, want 4 and 3", runs, s.Restarts())
+	}
+	if !errors.Is(gaveUp, errTest) || !errors.Is(s.Err(), errTest) {
+		t.Fatalf("got %v and %v, want the last error", gaveUp, s.Err())
+	}
+
+	// Restarts outside the window don't count.
+	runs = 0
+	s = try.SuperviseWith(context.Background(), try.SuperviseConfig{MaxRestarts: 1, Window: 5 * time.Millisecond}, func() {
+		if runs++; runs <= 3 {
+			time.Sleep(10 * time.Millisecond)
+			panic(errTest)
+		}
+	})
+	<-s.Done()
+	if runs != 4 {
+		t.Fatalf("got %d runs, want 4 without giving up", runs)
+	}
+}
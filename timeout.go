@@ -0,0 +1,79 @@
+package try
+
+import (
+	"context"
+	"time"
+)
+
+// WithTimeout runs fn safely in a goroutine and returns its error,
+// or ErrTimeout if fn doesn't complete within d.
+// fn can't be stopped and keeps running after a timeout, so it should be cancellable by other means.
+func WithTimeout(d time.Duration, fn func() error) error {
+	ch := make(chan error, 1)
+	go func() {
+		ch <- CallResult(fn)
+	}()
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case err := <-ch:
+		return err
+	case <-timer.C:
+		return ErrTimeout
+	}
+}
+
+// DeadlineVal runs produce in a goroutine and returns its value like Val,
+// or panics with ctx.Err() if ctx is done before produce returns.
+// produce can't be stopped and keeps running after ctx is done.
+func DeadlineVal[T any](ctx context.Context, produce func() (T, error)) T {
+	type result struct {
+		v   T
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		var r result
+		r.err = CallResult(func() (err error) {
+			r.v, err = produce()
+			return
+		})
+		ch <- r
+	}()
+	var r result
+	select {
+	case r = <-ch:
+	case <-ctx.Done():
+		r.err = ctx.Err()
+	}
+	checkErr(r.err)
+	return r.v
+}
+
+// AfterFunc is like time.AfterFunc, but runs fn safely: its panics are recovered and logged like by Catch(nil).
+func AfterFunc(d time.Duration, fn func()) *time.Timer {
+	return time.AfterFunc(d, func() {
+		defer Catch(nil)
+		fn()
+	})
+}
+
+// CallTimed runs fn safely and returns the elapsed time and the recovered panic-error.
+// The time is measured whether fn panics or not.
+func CallTimed(fn func()) (time.Duration, error) {
+	start := time.Now()
+	err := Call(fn)
+	return time.Since(start), err
+}
+
+// CatchTimed recovers and sets error by err pointer like Catch, and calls onSlow with the elapsed time
+// if the recovery handling, including the OnPanic hooks, took longer than budget.
+func CatchTimed(err *error, budget time.Duration, onSlow func(time.Duration)) {
+	if r := recover(); r != nil {
+		start := time.Now()
+		catch(err, r)
+		if d := time.Since(start); d > budget && onSlow != nil {
+			onSlow(d)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package try_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goldic/try"
+)
+
+func TestWithTimeout(t *testing.T) {
+	if err := try.WithTimeout(time.Second, func() error { return nil }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := try.WithTimeout(time.Second, func() error { return errTest }); err != errTest {
+		t.Fatalf("got %v, want %v", err, errTest)
+	}
+	if err := try.WithTimeout(time.Second, func() error { panic("boom") }); !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %v, want the panic", err)
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	start := time.Now()
+	err := try.WithTimeout(10*time.Millisecond, func() error { <-release; return nil })
+	if err != try.ErrTimeout {
+		t.Fatalf("got %v, want %v", err, try.ErrTimeout)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Fatalf("returned after %v, want promptly after the timeout", d)
+	}
+}
+
+func TestDeadlineVal(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	var got int
+	if err := try.Call(func() { got = try.DeadlineVal(ctx, func() (int, error) { return 42, nil }) }); err != nil || got != 42 {
+		t.Fatalf("got %d, %v, want 42, nil", got, err)
+	}
+	if err := try.Call(func() { try.DeadlineVal(ctx, func() (int, error) { return 0, errTest }) }); !errors.Is(err, errTest) {
+		t.Fatalf("got %v, want %v", err, errTest)
+	}
+	if err := try.Call(func() { try.DeadlineVal(ctx, func() (int, error) { panic("boom") }) }); !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %v, want the panic-error", err)
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := try.Call(func() { try.DeadlineVal(ctx, func() (int, error) { <-release; return 1, nil }) })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestAfterFunc(t *testing.T) {
+	logged := make(chan string, 1)
+	try.SetLogger(func(format string, args ...any) { logged <- fmt.Sprintf(format, args...) })
+	t.Cleanup(func() { try.SetLogger(nil) })
+
+	try.AfterFunc(time.Millisecond, func() { panic("boom") })
+	select {
+	case msg := <-logged:
+		if !strings.HasPrefix(msg, "Panic: boom") {
+			t.Fatalf("got %q, want the panic logged", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("callback panic not logged")
+	}
+}
+
+func TestCallTimed(t *testing.T) {
+	d, err := try.CallTimed(func() { time.Sleep(5 * time.Millisecond) })
+	if err != nil || d < 5*time.Millisecond {
+		t.Fatalf("got %v, %v, want at least 5ms and no error", d, err)
+	}
+	d, err = try.CallTimed(func() {
+		time.Sleep(5 * time.Millisecond)
+		try.Check(errTest)
+	})
+	if !errors.Is(err, errTest) || d < 5*time.Millisecond {
+		t.Fatalf("got %v, %v, want the time measured for a panic too", d, err)
+	}
+}
+
+func TestCatchTimed(t *testing.T) {
+	var slow []time.Duration
+	onSlow := func(d time.Duration) { slow = append(slow, d) }
+	run := func(fn func()) (err error) {
+		defer try.CatchTimed(&err, 5*time.Millisecond, onSlow)
+		fn()
+		return nil
+	}
+
+	if err := run(func() { try.Check(errTest) }); !errors.Is(err, errTest) || len(slow) != 0 {
+		t.Fatalf("got %v, slow %v, want a fast recovery", err, slow)
+	}
+
+	setHook(t, func(error) { time.Sleep(10 * time.Millisecond) }) // a slow OnPanic hook
+	if err := run(func() { try.Check(errTest) }); !errors.Is(err, errTest) || len(slow) != 1 || slow[0] < 10*time.Millisecond {
+		t.Fatalf("got %v, slow %v, want the slow recovery reported", err, slow)
+	}
+	if err := run(func() {}); err != nil || len(slow) != 1 {
+		t.Fatalf("got %v, slow %v, want nothing measured without a panic", err, slow)
+	}
+}
@@ -1,11 +1,14 @@
 package try
 
 import (
+	"cmp"
+	"context"
 	"errors"
 	"fmt"
-	"log"
 	"runtime"
-	"sync"
+	"runtime/debug"
+	"slices"
+	"strings"
 )
 
 // OK panics when err is not null.
@@ -18,12 +21,117 @@ func Check(err error) {
 	checkErr(err)
 }
 
+// OKf panics when err is not null, wrapping err with the formatted message.
+// Nothing is formatted when err is null, but the caller still boxes non-constant args into the variadic slice.
+func OKf(err error, format string, args ...any) {
+	if err != nil {
+		checkErr(wrapf(err, format, args))
+	}
+}
+
+// Checkf panics when err is not null, wrapping err with the formatted message.
+// Nothing is formatted when err is null, but the caller still boxes non-constant args into the variadic slice.
+func Checkf(err error, format string, args ...any) {
+	if err != nil {
+		checkErr(wrapf(err, format, args))
+	}
+}
+
+// OKAll panics when any of errs is not null, with all non-nil errors joined.
+func OKAll(errs ...error) {
+	for _, err := range errs {
+		if err != nil {
+			checkErr(Join(errs...))
+		}
+	}
+}
+
+// CheckHint panics when err is not null, annotating err with a human-readable remediation hint (see Hint).
+func CheckHint(err error, hint string) {
+	if err != nil {
+		checkErr(&hintError{err: err, hint: hint})
+	}
+}
+
 // Val returns v or panics when err is not null.
 func Val[T any](v T, err error) T {
 	checkErr(err)
 	return v
 }
 
+// Valf returns v or panics when err is not null, wrapping err with the formatted message.
+// Nothing is formatted when err is null, but the caller still boxes non-constant args into the variadic slice.
+func Valf[T any](v T, err error, format string, args ...any) T {
+	if err != nil {
+		checkErr(wrapf(err, format, args))
+	}
+	return v
+}
+
+// ValNamed returns v or panics when err is not null, wrapping err with the operation name like "op: err".
+func ValNamed[T any](op string, v T, err error) T {
+	if err != nil {
+		checkErr(fmt.Errorf("%s: %w", op, err))
+	}
+	return v
+}
+
+// TeeVal returns v or panics when err is not null. On success it calls tap with v before returning it.
+func TeeVal[T any](v T, err error, tap func(T)) T {
+	checkErr(err)
+	tap(v)
+	return v
+}
+
+// ValCtx returns v or panics when ctx is done or err is not null.
+func ValCtx[T any](ctx context.Context, v T, err error) T {
+	checkErr(ctx.Err())
+	checkErr(err)
+	return v
+}
+
+// ValDeref returns *p or panics when err is not null or p is nil.
+func ValDeref[T any](p *T, err error) T {
+	checkErr(err)
+	if p == nil {
+		checkErr(ErrNilPointer)
+	}
+	return *p
+}
+
+// ValMap returns m or panics when err is not null. A nil m is replaced with an empty map.
+func ValMap[K comparable, V any](m map[K]V, err error) map[K]V {
+	checkErr(err)
+	if m == nil {
+		return map[K]V{}
+	}
+	return m
+}
+
+// ValBytes returns b or panics when err is not null. A nil b is replaced with an empty slice.
+func ValBytes(b []byte, err error) []byte {
+	checkErr(err)
+	if b == nil {
+		return []byte{}
+	}
+	return b
+}
+
+// ValString returns s or panics when err is not null.
+func ValString(s string, err error) string {
+	checkErr(err)
+	return s
+}
+
+// ValRange returns v or panics when err is not null or v is outside [min, max].
+func ValRange[T cmp.Ordered](v T, err error, min, max T) T {
+	checkErr(err)
+	if !(v >= min && v <= max) { // also rejects NaN
+		checkErr(fmt.Errorf("%w: %v not in [%v, %v]", ErrOutOfRange, v, min, max))
+	}
+	return v
+}
+
 // Val2 returns v1, v2 or panics when err is not null.
 func Val2[T1, T2 any](v1 T1, v2 T2, err error) (T1, T2) {
 	checkErr(err)
@@ -36,52 +144,421 @@ func Val3[T1, T2, T3 any](v1 T1, v2 T2, v3 T3, err error) (T1, T2, T3) {
 	return v1, v2, v3
 }
 
-// SafeVal returns v and ignores error.
+// Val4 returns v1, v2, v3, v4 or panics when err is not null.
+func Val4[T1, T2, T3, T4 any](v1 T1, v2 T2, v3 T3, v4 T4, err error) (T1, T2, T3, T4) {
+	checkErr(err)
+	return v1, v2, v3, v4
+}
+
+// Val5 returns v1, v2, v3, v4, v5 or panics when err is not null.
+func Val5[T1, T2, T3, T4, T5 any](v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, err error) (T1, T2, T3, T4, T5) {
+	checkErr(err)
+	return v1, v2, v3, v4, v5
+}
+
+// Val6 returns v1, v2, v3, v4, v5, v6 or panics when err is not null.
+func Val6[T1, T2, T3, T4, T5, T6 any](v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, err error) (T1, T2, T3, T4, T5, T6) {
+	checkErr(err)
+	return v1, v2, v3, v4, v5, v6
+}
+
+// Val7 returns v1, v2, v3, v4, v5, v6, v7 or panics when err is not null.
+func Val7[T1, T2, T3, T4, T5, T6, T7 any](v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, err error) (T1, T2, T3, T4, T5, T6, T7) {
+	checkErr(err)
+	return v1, v2, v3, v4, v5, v6, v7
+}
+
+// Val8 returns v1, v2, v3, v4, v5, v6, v7, v8 or panics when err is not null.
+func Val8[T1, T2, T3, T4, T5, T6, T7, T8 any](v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, v7 T7, v8 T8, err error) (T1, T2, T3, T4, T5, T6, T7, T8) {
+	checkErr(err)
+	return v1, v2, v3, v4, v5, v6, v7, v8
+}
+
+// Must is an alias for Val: it returns v or panics when err is not null.
+func Must[T any](v T, err error) T {
+	checkErr(err)
+	return v
+}
+
+// Must2 is an alias for Val2: it returns v1, v2 or panics when err is not null.
+func Must2[T1, T2 any](v1 T1, v2 T2, err error) (T1, T2) {
+	checkErr(err)
+	return v1, v2
+}
+
+// Must3 is an alias for Val3: it returns v1, v2, v3 or panics when err is not null.
+func Must3[T1, T2, T3 any](v1 T1, v2 T2, v3 T3, err error) (T1, T2, T3) {
+	checkErr(err)
+	return v1, v2, v3
+}
+
+// Muster returns a function which calls fn and returns its value or panics when its error is not null.
+func Muster[T any](fn func() (T, error)) func() T {
+	return func() T {
+		v, err := fn()
+		checkErr(err)
+		return v
+	}
+}
+
+// Decorate is an alias for Muster: it returns a function which calls fn and panics when its error is not null.
+func Decorate[T any](fn func() (T, error)) func() T {
+	return Muster(fn)
+}
+
+// Decorate2 is like Decorate for functions returning two values.
+func Decorate2[T1, T2 any](fn func() (T1, T2, error)) func() (T1, T2) {
+	return func() (T1, T2) {
+		v1, v2, err := fn()
+		checkErr(err)
+		return v1, v2
+	}
+}
+
+// Decorate3 is like Decorate for functions returning three values.
+func Decorate3[T1, T2, T3 any](fn func() (T1, T2, T3, error)) func() (T1, T2, T3) {
+	return func() (T1, T2, T3) {
+		v1, v2, v3, err := fn()
+		checkErr(err)
+		return v1, v2, v3
+	}
+}
+
+// SafeVal returns v and ignores error, unless strict mode is enabled (see SetStrictSafe).
 func SafeVal[T any](v T, err error) T {
-	// ignore error
+	ignoreErr(err)
 	return v
 }
 
-// SafeVal2 returns v1, v2 and ignores error.
+// SafeVal2 returns v1, v2 and ignores error, unless strict mode is enabled (see SetStrictSafe).
 func SafeVal2[T1, T2 any](v1 T1, v2 T2, err error) (T1, T2) {
-	// ignore error
+	ignoreErr(err)
 	return v1, v2
 }
 
-// SafeVal3 returns v1, v2, v3 and ignores error.
+// SafeVal3 returns v1, v2, v3 and ignores error, unless strict mode is enabled (see SetStrictSafe).
 func SafeVal3[T1, T2, T3 any](v1 T1, v2 T2, v3 T3, err error) (T1, T2, T3) {
-	// ignore error
+	ignoreErr(err)
 	return v1, v2, v3
 }
 
+// SafeVal4 returns v1, v2, v3, v4 and ignores error, unless strict mode is enabled (see SetStrictSafe).
+func SafeVal4[T1, T2, T3, T4 any](v1 T1, v2 T2, v3 T3, v4 T4, err error) (T1, T2, T3, T4) {
+	ignoreErr(err)
+	return v1, v2, v3, v4
+}
+
+// SafeVal5 returns v1, v2, v3, v4, v5 and ignores error, unless strict mode is enabled (see SetStrictSafe).
+func SafeVal5[T1, T2, T3, T4, T5 any](v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, err error) (T1, T2, T3, T4, T5) {
+	ignoreErr(err)
+	return v1, v2, v3, v4, v5
+}
+
+// SafeVal6 returns v1, v2, v3, v4, v5, v6 and ignores error, unless strict mode is enabled (see SetStrictSafe).
+func SafeVal6[T1, T2, T3, T4, T5, T6 any](v1 T1, v2 T2, v3 T3, v4 T4, v5 T5, v6 T6, err error) (T1, T2, T3, T4, T5, T6) {
+	ignoreErr(err)
+	return v1, v2, v3, v4, v5, v6
+}
+
+// ValOr returns v, or fallback when err is not null.
+func ValOr[T any](v T, err error, fallback T) T {
+	if err != nil {
+		return fallback
+	}
+	return v
+}
+
+// ValOrElse returns v, or the result of fn(err) when err is not null.
+func ValOrElse[T any](v T, err error, fn func(error) T) T {
+	if err != nil {
+		return fn(err)
+	}
+	return v
+}
+
+// ValOrZero returns v, or the zero value of T when err is not null.
+func ValOrZero[T any](v T, err error) (zero T) {
+	if err != nil {
+		return zero
+	}
+	return v
+}
+
 // Require panics if statement is false.
 func Require(statement bool, err any) {
 	if !statement {
-		checkErr(toError(err))
+		checkErr(newError(err))
 	}
 }
 
+// Requiref panics with the formatted message if statement is false.
+// The message is only formatted when statement is false, but the caller still boxes non-constant args.
+func Requiref(statement bool, format string, args ...any) {
+	if !statement {
+		checkErr(fmt.Errorf(format, args...))
+	}
+}
+
+// errRequirement is raised by a failed requirement whose error is nil.
+var errRequirement = errors.New("try: requirement failed")
+
+// RequireErr panics with err if statement is false. The identity of err is preserved for errors.Is.
+// A nil err is replaced with a generic error, so a failed requirement always panics.
+func RequireErr(statement bool, err error) {
+	if !statement {
+		if err == nil {
+			err = errRequirement
+		}
+		checkErr(err)
+	}
+}
+
+// RequireFn panics with the error returned by errFn if statement is false.
+// errFn is only called when statement is false. A nil error is replaced with a generic one.
+func RequireFn(statement bool, errFn func() error) {
+	if !statement {
+		err := errFn()
+		if err == nil {
+			err = errRequirement
+		}
+		checkErr(err)
+	}
+}
+
+// Assert panics with msg if cond is false.
+func Assert(cond bool, msg string) {
+	if !cond {
+		checkErr(errors.New(msg))
+	}
+}
+
+// Assertf panics with the formatted message if cond is false.
+func Assertf(cond bool, format string, args ...any) {
+	if !cond {
+		checkErr(fmt.Errorf(format, args...))
+	}
+}
+
+// RequireEqual panics with a message like "got X, want Y" if got is not equal to want.
+func RequireEqual[T comparable](got, want T) {
+	if got != want {
+		checkErr(fmt.Errorf("got %v, want %v", got, want))
+	}
+}
+
+// RequireNoErr is an alias for OK: it panics when err is not null.
+func RequireNoErr(err error) {
+	checkErr(err)
+}
+
 // Handle recovers error and call fn error-handler.
 func Handle(fn func(err error)) {
 	if r := recover(); r != nil {
-		fn(toError(r))
+		fn(handlePanic(r))
+	}
+}
+
+// HandleLoc recovers error and calls fn error-handler with the location where a try helper raised it.
+// For other panics file is empty and line is zero.
+func HandleLoc(fn func(err error, file string, line int)) {
+	if r := recover(); r != nil {
+		err := handlePanic(r)
+		var e *panicError
+		if errors.As(err, &e) {
+			fn(err, e.file, e.line)
+			return
+		}
+		fn(err, "", 0)
+	}
+}
+
+// HandleIf recovers error and calls fn error-handler if pred returns true, otherwise the panic is re-raised.
+func HandleIf(pred func(err error) bool, fn func(err error)) {
+	if r := recover(); r != nil {
+		err := toError(r)
+		if !pred(err) {
+			panic(r)
+		}
+		notifyPanic(err)
+		fn(err)
+	}
+}
+
+// CatchRethrow recovers, calls fn error-handler and re-raises the panic with its original value.
+func CatchRethrow(fn func(err error)) {
+	if r := recover(); r != nil {
+		rethrow(r, fn)
+	}
+}
+
+// CatchValue recovers and calls fn with the original panic value, without converting it to an error.
+func CatchValue(fn func(v any)) {
+	if r := recover(); r != nil {
+		handlePanic(r)
+		fn(r)
 	}
 }
 
 // Catch recovers and sets error by err pointer.
+// An error already set by err pointer is joined with the recovered one.
 func Catch(err *error) {
 	if r := recover(); r != nil {
+		catch(err, r)
+	}
+}
+
+// CatchFresh recovers and sets error by err pointer like Catch, but overwrites the error already set instead of joining.
+func CatchFresh(err *error) {
+	if r := recover(); r != nil {
+		if err == nil {
+			catch(nil, r)
+			return
+		}
+		*err = handlePanic(r)
+	}
+}
+
+// CatchInto recovers and appends error to the slice by errs pointer.
+func CatchInto(errs *[]error) {
+	if r := recover(); r != nil {
+		if errs == nil {
+			catch(nil, r)
+			return
+		}
+		*errs = append(*errs, handlePanic(r))
+	}
+}
+
+// CatchReset recovers and sets error by err pointer like Catch, then calls reset, with or without a panic.
+func CatchReset(err *error, reset func()) {
+	defer reset()
+	if r := recover(); r != nil {
+		catch(err, r)
+	}
+}
+
+// CatchConvert recovers and sets error by err pointer, converting the panic value with convert.
+// The panic is swallowed if convert returns nil; it is still counted and passed to the OnPanic hooks.
+func CatchConvert(err *error, convert func(r any) error) {
+	if r := recover(); r != nil {
+		e := convert(r)
+		if e == nil {
+			handlePanic(r)
+			return
+		}
+		notifyPanic(e)
 		if err == nil { // log error
-			log.Printf("Panic: %v", r)
+			logf("Panic: %v", e)
+			return
+		}
+		*err = joinErrors(*err, e)
+	}
+}
+
+// CatchOnly recovers panics raised by try helpers and sets error by err pointer.
+// Any other panic (nil dereference, index out of range, ...) is re-raised.
+func CatchOnly(err *error) {
+	if r := recover(); r != nil {
+		if _, ok := r.(*panicError); !ok {
+			panic(r)
+		}
+		catch(err, r)
+	}
+}
+
+// CatchAs recovers errors matching type T (see errors.As) and calls handler; any other panic is re-raised.
+func CatchAs[T error](handler func(T)) {
+	if r := recover(); r != nil {
+		var target T
+		if e, ok := r.(error); ok && errors.As(e, &target) {
+			handlePanic(r)
+			handler(target)
 			return
 		}
-		*err = joinErrors(*err, toError(r))
+		panic(r)
+	}
+}
+
+// Recovered converts the value returned by recover() to an error, or returns nil if there was no panic.
+// recover() only stops a panic when called directly by the deferred function, so pass its result:
+//
+//	defer func() {
+//		if err := try.Recovered(recover()); err != nil {
+//			// handle or re-panic
+//		}
+//	}()
+func Recovered(r any) error {
+	if r == nil {
+		return nil
+	}
+	return toError(r)
+}
+
+// RecoverAs returns the value returned by recover() if it is of type T.
+// It returns false if there was no panic and re-raises a panic with a value of any other type:
+//
+//	defer func() {
+//		if sig, ok := try.RecoverAs[stopSignal](recover()); ok {
+//			// handle sig
+//		}
+//	}()
+func RecoverAs[T any](r any) (T, bool) {
+	if r == nil {
+		var zero T
+		return zero, false
+	}
+	v, ok := r.(T)
+	if !ok {
+		panic(r)
 	}
+	notifyPanic(toError(r))
+	return v, true
+}
+
+// AsError converts the recovered value r to an error prefixed with label.
+// Errors are wrapped with %w, other values are formatted with %v and match ErrPanic.
+func AsError(r any, label string) error {
+	if r == nil {
+		return nil
+	}
+	if label == "" {
+		return toError(r)
+	}
+	return fmt.Errorf("%s: %w", label, toError(r))
 }
 
 // Mute mutes panic-error.
 func Mute() {
-	recover()
+	if r := recover(); r != nil {
+		logMuted(handlePanic(r))
+	}
+}
+
+// MuteIf mutes panic-error if pred returns true, otherwise the panic is re-raised.
+func MuteIf(pred func(err error) bool) {
+	if r := recover(); r != nil {
+		err := toError(r)
+		if !pred(err) {
+			panic(r)
+		}
+		notifyPanic(err)
+		logMuted(err)
+	}
+}
+
+// Finally calls fn. Used with defer, fn runs whether a panic occurred or not,
+// and the panic in progress keeps propagating with its original value and stack.
+func Finally(fn func()) {
+	fn()
+}
+
+// OnError calls fn with the panic-error only if a panic is propagating, then re-raises the panic with its original value.
+// It is CatchRethrow under a name for the cleanup-on-failure idiom; both share rethrow, as recover
+// only works when called directly by the deferred function.
+func OnError(fn func(err error)) {
+	if r := recover(); r != nil {
+		rethrow(r, fn)
+	}
 }
 
 // Call runs the function safely, recovers panic-error.
@@ -91,34 +568,194 @@ func Call(fn func()) (err error) {
 	return
 }
 
+// CallResult runs the function safely and returns its error or the recovered panic-error.
+// A recovered panic is always distinguishable from a returned error: errors raised by the try helpers
+// match ErrTry, and other panics match ErrPanic, including panics with error values like panic(io.EOF),
+// which are wrapped and still match the original error with errors.Is.
+// If fn panics in a deferred call after returning an error, the returned error is lost
+// and only the panic is reported, as Go doesn't pass the results of a panicking function to its caller.
+func CallResult(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			catch(&err, markPanic(r))
+		}
+	}()
+	return fn()
+}
+
+// markPanic wraps the recovered error r so that it matches ErrPanic, unless it was raised by a try helper
+// or already matches ErrPanic.
+func markPanic(r any) any {
+	e, ok := r.(error)
+	if !ok || errors.Is(e, ErrPanic) {
+		return r
+	}
+	var pe *panicError
+	if errors.As(e, &pe) {
+		return r
+	}
+	return &panickedError{err: e}
+}
+
+// Try runs the function safely and returns the recovered panic-error, like Call.
+// It marks the boundary between try-style code and error-returning APIs.
+func Try(fn func()) error {
+	return Call(fn)
+}
+
+// Try1 runs the function safely and returns its result, or the zero value and the recovered panic-error.
+func Try1[T any](fn func() T) (v T, err error) {
+	defer Catch(&err)
+	return fn(), nil
+}
+
+// Safe returns a function which runs fn safely and returns the recovered panic-error.
+func Safe(fn func()) func() error {
+	return func() error {
+		return Call(fn)
+	}
+}
+
+// Safe1 returns a function which runs fn safely and returns its result or the recovered panic-error.
+func Safe1[T any](fn func() T) func() (T, error) {
+	return func() (T, error) {
+		return Try1(fn)
+	}
+}
+
+// Protect0 returns a function which runs fn safely and returns its error or the recovered panic-error.
+func Protect0(fn func() error) func() error {
+	return func() error {
+		return CallResult(fn)
+	}
+}
+
+// Protect1 is like Protect0 for functions with one argument and a result.
+func Protect1[A, R any](fn func(A) (R, error)) func(A) (R, error) {
+	return func(a A) (r R, err error) {
+		err = CallResult(func() (err error) {
+			r, err = fn(a)
+			return
+		})
+		return
+	}
+}
+
+// Protect2 is like Protect0 for functions with two arguments and a result.
+func Protect2[A, B, R any](fn func(A, B) (R, error)) func(A, B) (R, error) {
+	return func(a A, b B) (r R, err error) {
+		err = CallResult(func() (err error) {
+			r, err = fn(a, b)
+			return
+		})
+		return
+	}
+}
+
+// Recoverer returns a decorator which makes a function safe: its panics are recovered and passed to onPanic.
+func Recoverer(onPanic func(err error)) func(next func()) func() {
+	return func(next func()) func() {
+		return func() {
+			if err := Call(next); err != nil {
+				onPanic(err)
+			}
+		}
+	}
+}
+
+// CallWith runs the function safely like Call and returns the recovered panic-error passed through transform.
+// A panic in transform is recovered and joined with the original error.
+func CallWith(fn func(), transform func(error) error) (err error) {
+	if err = Call(fn); err != nil {
+		defer Catch(&err)
+		err = transform(err)
+	}
+	return
+}
+
+// WrapPanic runs the function safely and returns the recovered panic-error wrapped with msg.
+func WrapPanic(msg string, fn func()) error {
+	if err := Call(fn); err != nil {
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	return nil
+}
+
+// Invoke runs the callback fn safely and returns its error or recovered panic-error
+// attributed to the callback name, e.g. `"foo" failed: <error>`.
+func Invoke(name string, fn func() error) error {
+	if err := CallResult(fn); err != nil {
+		return fmt.Errorf("%q failed: %w", name, err)
+	}
+	return nil
+}
+
 // Go runs the function safely.
+// It blocks while the number of running goroutines is at the limit set by SetMaxGoroutines.
 func Go(fn func()) {
-	go Call(fn)
-}
-
-// Async asynchronously runs several functions and waits for them to complete, returns an error in case of panic.
-func Async(fn ...func()) (err error) {
-	var wg sync.WaitGroup
-	wg.Add(len(fn))
-	var mxErr sync.Mutex
-	for _, f := range fn {
-		go func(fn func()) {
-			defer wg.Done()
-			defer func() {
-				if r := recover(); r != nil {
-					mxErr.Lock()
-					defer mxErr.Unlock()
-					err = joinErrors(err, toError(r))
-				}
-			}()
-			fn()
-		}(f)
-	}
-	wg.Wait()
-	return
+	p := goSem.Load()
+	if p == nil {
+		go Call(fn)
+		return
+	}
+	sem := *p
+	sem <- struct{}{}
+	go func() {
+		defer func() { <-sem }()
+		Call(fn)
+	}()
+}
+
+// GoCtx runs fn safely in a goroutine like Go, passing ctx through.
+// Recovered panics are logged, annotated with the registered context values (see RegisterContextKey).
+func GoCtx(ctx context.Context, fn func(ctx context.Context)) {
+	Go(func() {
+		if err := Call(func() { fn(ctx) }); err != nil {
+			logf("Panic: %v", withContext(ctx, err))
+		}
+	})
 }
 
-func toError(err any) error {
+// GoWithErr runs the function safely in a goroutine and returns a channel,
+// which receives the recovered panic-error (or nil) and is then closed.
+func GoWithErr(fn func()) <-chan error {
+	ch := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		ch <- Call(fn)
+	}()
+	return ch
+}
+
+func catch(err *error, r any) error {
+	e := handlePanic(r)
+	if err == nil { // log error with the stack of the panic
+		logf("Panic: %v\n%s", r, debug.Stack())
+		return e
+	}
+	*err = joinErrors(*err, e)
+	return e
+}
+
+// rethrow calls fn with the panic-error of the recovered value r and re-raises the panic with r.
+func rethrow(r any, fn func(err error)) {
+	fn(toError(r))
+	panic(r)
+}
+
+func wrapf(err error, format string, args []any) error {
+	return fmt.Errorf(format+": %w", append(args[:len(args):len(args)], err)...)
+}
+
+// toError converts the recovered panic value r to an error.
+func toError(r any) error {
+	if e, ok := r.(error); ok {
+		return e
+	}
+	return &valueError{r}
+}
+
+func newError(err any) error {
 	if e, ok := err.(error); ok {
 		return e
 	}
@@ -132,9 +769,84 @@ func joinErrors(a, b error) error {
 	return errors.Join(a, b)
 }
 
+func joinSlice(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	return &ErrorList{errs: slices.Clone(errs)}
+}
+
 func checkErr(err error) {
 	if err != nil {
-		_, file, line, _ := runtime.Caller(2)
-		panic(fmt.Errorf("%w\n\t%s:%d", err, file, line))
+		raise(err)
+	}
+}
+
+// raise is the cold path of checkErr; keeping it separate lets checkErr be inlined.
+//
+//go:noinline
+func raise(err error) {
+	panicCount.Add(1)
+	e := &panicError{err: err}
+	format := DefaultLocationFormat
+	if p := locationFormat.Load(); p != nil {
+		format = *p
+	}
+	if format != nil {
+		e.file, e.line = callerLocation()
+		if le := format(err, e.file, e.line); le != nil {
+			e.err = le
+		}
+	}
+	if captureStack.Load() {
+		e.stack = externalCallers()
+	}
+	panic(e)
+}
+
+// ignoreErr panics when err is not null in strict mode, otherwise it ignores err.
+func ignoreErr(err error) {
+	if err != nil && strictSafe.Load() {
+		checkErr(fmt.Errorf("try: ignored error: %w", err))
+	}
+}
+
+// pkgPrefix is the function name prefix of the try package, e.g. "github.com/goldic/try.".
+var pkgPrefix = packagePrefix()
+
+func packagePrefix() string {
+	pc, _, _, _ := runtime.Caller(0)
+	name := runtime.FuncForPC(pc).Name()
+	i := strings.LastIndex(name, "/")
+	return name[:i+strings.Index(name[i:], ".")+1]
+}
+
+// callerLocation returns the location of the first caller outside the try package.
+// The helpers are rarely more than a few frames deep, so a short stack walk is tried first.
+func callerLocation() (file string, line int) {
+	var short [4]uintptr
+	pcs := short[:runtime.Callers(2, short[:])]
+	if frame, ok := externalFrame(pcs); ok || len(pcs) < len(short) {
+		return frame.File, frame.Line
+	}
+	var long [maxStackDepth]uintptr
+	frame, _ := externalFrame(long[:runtime.Callers(2, long[:])])
+	return frame.File, frame.Line
+}
+
+// externalFrame returns the first frame of pcs outside the try package, or the last frame.
+func externalFrame(pcs []uintptr) (runtime.Frame, bool) {
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, pkgPrefix) {
+			return frame, true
+		}
+		if !more {
+			return frame, false
+		}
 	}
 }
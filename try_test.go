@@ -0,0 +1,1023 @@
+package try_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goldic/try"
+	"github.com/goldic/try/trytest"
+)
+
+var errTest = errors.New("test error")
+
+func TestValRange(t *testing.T) {
+	trytest.NoPanic(t, func() {
+		if v := try.ValRange(5, nil, 1, 10); v != 5 {
+			t.Fatalf("got %d, want 5", v)
+		}
+		try.ValRange(1, nil, 1, 10)
+		try.ValRange(10, nil, 1, 10)
+	})
+	trytest.PanicsWith(t, errTest, func() { try.ValRange(5, errTest, 1, 10) })
+	trytest.PanicsWith(t, try.ErrOutOfRange, func() { try.ValRange(0, nil, 1, 10) })
+	trytest.PanicsWith(t, try.ErrOutOfRange, func() { try.ValRange(11, nil, 1, 10) })
+	trytest.PanicsWith(t, try.ErrOutOfRange, func() { try.ValRange(math.NaN(), nil, 0.0, 1.0) })
+
+	err := try.Call(func() { try.ValRange(11, nil, 1, 10) })
+	if want := "try: out of range: 11 not in [1, 10]"; !errors.Is(err, try.ErrOutOfRange) || !strings.HasPrefix(err.Error(), want) {
+		t.Fatalf("got %q, want %q", err, want)
+	}
+}
+
+func TestRequireErr(t *testing.T) {
+	trytest.NoPanic(t, func() { try.RequireErr(true, errTest) })
+	trytest.PanicsWith(t, errTest, func() { try.RequireErr(false, errTest) })
+	if err := try.Call(func() { try.RequireErr(false, nil) }); err == nil {
+		t.Fatal("expected panic for a failed requirement with a nil error")
+	}
+}
+
+func TestRequireFn(t *testing.T) {
+	called := false
+	trytest.NoPanic(t, func() { try.RequireFn(true, func() error { called = true; return errTest }) })
+	if called {
+		t.Fatal("errFn called for a true statement")
+	}
+	trytest.PanicsWith(t, errTest, func() { try.RequireFn(false, func() error { return errTest }) })
+	if err := try.Call(func() { try.RequireFn(false, func() error { return nil }) }); err == nil {
+		t.Fatal("expected panic for a failed requirement with a nil error")
+	}
+}
+
+func TestOKf(t *testing.T) {
+	trytest.NoPanic(t, func() {
+		try.OKf(nil, "connecting to %s", "db")
+		try.Checkf(nil, "connecting to %s", "db")
+	})
+	for _, fn := range []func(error, string, ...any){try.OKf, try.Checkf} {
+		err := try.Call(func() { fn(errTest, "connecting to %s", "db") })
+		if !errors.Is(err, errTest) {
+			t.Fatalf("got %v, want %v", err, errTest)
+		}
+		if want := "connecting to db: test error"; !strings.HasPrefix(err.Error(), want) {
+			t.Fatalf("got %q, want %q", err, want)
+		}
+	}
+}
+
+func TestOKfAllocs(t *testing.T) {
+	// Constant args are boxed statically, so the nil path makes no allocations.
+	if n := testing.AllocsPerRun(100, func() { try.OKf(nil, "connecting to %s:%d", "db", 5432) }); n != 0 {
+		t.Fatalf("OKf: got %v allocs, want 0", n)
+	}
+	if n := testing.AllocsPerRun(100, func() { try.Checkf(nil, "connecting") }); n != 0 {
+		t.Fatalf("Checkf: got %v allocs, want 0", n)
+	}
+}
+
+func TestValf(t *testing.T) {
+	trytest.NoPanic(t, func() {
+		if v := try.Valf(42, nil, "load %q", "cfg"); v != 42 {
+			t.Fatalf("got %d, want 42", v)
+		}
+	})
+	err := try.Call(func() { try.Valf(0, errTest, "load %q", "cfg") })
+	if !errors.Is(err, errTest) {
+		t.Fatalf("got %v, want %v", err, errTest)
+	}
+	if want := `load "cfg": test error`; !strings.HasPrefix(err.Error(), want) {
+		t.Fatalf("got %q, want %q", err, want)
+	}
+	if n := testing.AllocsPerRun(100, func() { try.Valf(42, nil, "load %q", "cfg") }); n != 0 {
+		t.Fatalf("got %v allocs, want 0", n)
+	}
+}
+
+func TestRequiref(t *testing.T) {
+	var formatted stringer
+	trytest.NoPanic(t, func() { try.Requiref(true, "status %v", &formatted) })
+	if formatted.calls != 0 {
+		t.Fatal("message formatted for a true statement")
+	}
+	err := try.Call(func() { try.Requiref(false, "status %d", 500) })
+	if want := "status 500"; err == nil || !strings.HasPrefix(err.Error(), want) {
+		t.Fatalf("got %v, want %q", err, want)
+	}
+	status := 200
+	if n := testing.AllocsPerRun(100, func() {
+		try.RequireFn(status == 200, func() error { return fmt.Errorf("status %d", status) })
+	}); n != 0 {
+		t.Fatalf("RequireFn: got %v allocs, want 0", n)
+	}
+}
+
+type stringer struct{ calls int }
+
+func (s *stringer) String() string {
+	s.calls++
+	return "stringer"
+}
+
+// captureLog redirects the try logger to the returned slice until the end of the test.
+func captureLog(t *testing.T) *[]string {
+	t.Helper()
+	var lines []string
+	try.SetLogger(func(format string, args ...any) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	})
+	t.Cleanup(func() { try.SetLogger(nil) })
+	return &lines
+}
+
+func TestOnError(t *testing.T) {
+	for name, onError := range map[string]func(func(error)){"OnError": try.OnError, "CatchRethrow": try.CatchRethrow} {
+		t.Run(name, func(t *testing.T) {
+			var got error
+			func() {
+				defer onError(func(err error) { got = err })
+			}()
+			if got != nil {
+				t.Fatalf("handler called without a panic: %v", got)
+			}
+
+			r := func() (r any) {
+				defer func() { r = recover() }()
+				defer onError(func(err error) { got = err })
+				panic(errTest)
+			}()
+			if r != errTest || got != errTest {
+				t.Fatalf("got %v and %v, want %v re-raised and handled", r, got, errTest)
+			}
+		})
+	}
+}
+
+func TestValN(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"pass-through", nil},
+		{"error", errTest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := try.Call(func() {
+				a, b, c, d := try.Val4(1, "2", 3.0, true, tt.err)
+				if a != 1 || b != "2" || c != 3.0 || !d {
+					t.Fatalf("Val4: got %v %v %v %v", a, b, c, d)
+				}
+				v1, v2, v3, v4, v5, v6, v7, v8 := try.Val8(1, 2, 3, 4, 5, 6, 7, 8, tt.err)
+				if got := []int{v1, v2, v3, v4, v5, v6, v7, v8}; !slices.Equal(got, []int{1, 2, 3, 4, 5, 6, 7, 8}) {
+					t.Fatalf("Val8: got %v", got)
+				}
+			})
+			if !errors.Is(err, tt.err) || (tt.err == nil) != (err == nil) {
+				t.Fatalf("got %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestSafeValN(t *testing.T) {
+	for _, err := range []error{nil, errTest} {
+		trytest.NoPanic(t, func() {
+			a, b, c, d := try.SafeVal4(1, "2", 3.0, true, err)
+			if a != 1 || b != "2" || c != 3.0 || !d {
+				t.Fatalf("SafeVal4: got %v %v %v %v", a, b, c, d)
+			}
+			v1, v2, v3, v4, v5 := try.SafeVal5(1, 2, 3, 4, 5, err)
+			if got := []int{v1, v2, v3, v4, v5}; !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+				t.Fatalf("SafeVal5: got %v", got)
+			}
+			v1, v2, v3, v4, v5, v6 := try.SafeVal6(1, 2, 3, 4, 5, 6, err)
+			if got := []int{v1, v2, v3, v4, v5, v6}; !slices.Equal(got, []int{1, 2, 3, 4, 5, 6}) {
+				t.Fatalf("SafeVal6: got %v", got)
+			}
+		})
+	}
+}
+
+func TestValOr(t *testing.T) {
+	if v := try.ValOr(1, nil, 2); v != 1 {
+		t.Fatalf("got %d, want 1", v)
+	}
+	if v := try.ValOr(1, errTest, 2); v != 2 {
+		t.Fatalf("got %d, want the fallback 2", v)
+	}
+
+	called := false
+	fallback := func(err error) int {
+		called = true
+		if err != errTest {
+			t.Fatalf("got %v, want %v", err, errTest)
+		}
+		return 2
+	}
+	if v := try.ValOrElse(1, nil, fallback); v != 1 || called {
+		t.Fatalf("got %d, fallback called: %v", v, called)
+	}
+	if v := try.ValOrElse(1, errTest, fallback); v != 2 || !called {
+		t.Fatalf("got %d, fallback called: %v", v, called)
+	}
+}
+
+func TestCatchOnly(t *testing.T) {
+	f := func(fn func()) (err error) {
+		defer try.CatchOnly(&err)
+		fn()
+		return nil
+	}
+	if err := f(func() { try.OK(errTest) }); !errors.Is(err, errTest) || !errors.Is(err, try.ErrTry) {
+		t.Fatalf("got %v, want %v", err, errTest)
+	}
+
+	r := func() (r any) {
+		defer func() { r = recover() }()
+		f(func() {
+			var m map[string]int
+			m["x"] = 1 // runtime panic
+		})
+		return nil
+	}()
+	if _, ok := r.(runtime.Error); !ok {
+		t.Fatalf("got %v, want the runtime panic re-raised", r)
+	}
+}
+
+// checkWrapper is a thin user wrapper around a try helper.
+func checkWrapper(err error) {
+	try.Check(err)
+}
+
+func TestLocation(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	err := try.Call(func() { try.Check(errTest) }) // line+1
+	file, got, ok := try.Location(err)
+	if !ok || !strings.HasSuffix(file, "try_test.go") || got != line+1 {
+		t.Fatalf("got %s:%d, want try_test.go:%d", file, got, line+1)
+	}
+
+	// Helpers calling each other report the caller outside try.
+	_, _, line, _ = runtime.Caller(0)
+	err = try.Call(func() { try.Muster(func() (int, error) { return 0, errTest })() }) // line+1
+	if _, got, _ := try.Location(err); got != line+1 {
+		t.Fatalf("got line %d, want %d", got, line+1)
+	}
+
+	// A wrapper outside try reports its own call of the helper, not a frame inside try.
+	err = try.Call(func() { checkWrapper(errTest) })
+	if file, _, ok := try.Location(err); !ok || !strings.HasSuffix(file, "try_test.go") {
+		t.Fatalf("got %s, want a location in try_test.go", file)
+	}
+
+	if _, _, ok := try.Location(errTest); ok {
+		t.Fatal("location reported for an error not raised by try")
+	}
+}
+
+func TestAssert(t *testing.T) {
+	trytest.NoPanic(t, func() {
+		try.Assert(true, "unreachable")
+		try.Assertf(true, "unreachable %d", 1)
+	})
+	for _, fn := range []func(){
+		func() { try.Assert(false, "n must be positive") },
+		func() { try.Assertf(false, "n must be %s", "positive") },
+	} {
+		err := try.Call(fn)
+		if err == nil || !strings.HasPrefix(err.Error(), "n must be positive") {
+			t.Fatalf("got %v, want the message", err)
+		}
+		if _, _, ok := try.Location(err); !ok {
+			t.Fatalf("no location attached to %v", err)
+		}
+	}
+}
+
+type customPanic struct {
+	Code int
+	Tags []string
+}
+
+func TestCatchValue(t *testing.T) {
+	var got any
+	func() {
+		defer try.CatchValue(func(v any) { got = v })
+		panic(customPanic{Code: 7, Tags: []string{"a"}})
+	}()
+	if v, ok := got.(customPanic); !ok || v.Code != 7 || v.Tags[0] != "a" {
+		t.Fatalf("got %#v, want the original value", got)
+	}
+
+	got = nil
+	func() {
+		defer try.CatchValue(func(v any) { got = v })
+	}()
+	if got != nil {
+		t.Fatalf("handler called without a panic: %v", got)
+	}
+}
+
+func TestFinally(t *testing.T) {
+	cleaned := false
+	func() {
+		defer try.Finally(func() { cleaned = true })
+	}()
+	if !cleaned {
+		t.Fatal("cleanup didn't run without a panic")
+	}
+
+	cleaned = false
+	r := func() (r any) {
+		defer func() { r = recover() }()
+		defer try.Finally(func() { cleaned = true })
+		panic(errTest)
+	}()
+	if !cleaned || r != errTest {
+		t.Fatalf("cleanup ran: %v, recovered %v, want the original panic value", cleaned, r)
+	}
+}
+
+type validationError struct{ field string }
+
+func (e *validationError) Error() string { return e.field + " is invalid" }
+
+func TestCatchAs(t *testing.T) {
+	var got *validationError
+	func() {
+		defer try.CatchAs(func(e *validationError) { got = e })
+		try.Check(fmt.Errorf("request: %w", &validationError{"name"}))
+	}()
+	if got == nil || got.field != "name" {
+		t.Fatalf("got %v, want the validation error", got)
+	}
+
+	for name, v := range map[string]any{"non-matching": errTest, "non-error": 42} {
+		t.Run(name, func(t *testing.T) {
+			r := func() (r any) {
+				defer func() { r = recover() }()
+				defer try.CatchAs(func(e *validationError) { t.Fatalf("handler called with %v", e) })
+				panic(v)
+			}()
+			if r != v {
+				t.Fatalf("got %v, want %v re-raised", r, v)
+			}
+		})
+	}
+}
+
+func TestRecovered(t *testing.T) {
+	var got error
+	func() {
+		defer func() { got = try.Recovered(recover()) }()
+		panic("boom")
+	}()
+	if !errors.Is(got, try.ErrPanic) || got.Error() != "boom" {
+		t.Fatalf("got %v, want the panic as an error", got)
+	}
+
+	func() {
+		defer func() { got = try.Recovered(recover()) }()
+	}()
+	if got != nil {
+		t.Fatalf("got %v without a panic, want nil", got)
+	}
+}
+
+func TestCallWith(t *testing.T) {
+	errAPI := errors.New("internal error")
+	called := false
+	transform := func(err error) error {
+		called = true
+		return fmt.Errorf("%w: %v", errAPI, err)
+	}
+	if err := try.CallWith(func() {}, transform); err != nil || called {
+		t.Fatalf("got %v, transform called: %v", err, called)
+	}
+	if err := try.CallWith(func() { panic("boom") }, transform); !errors.Is(err, errAPI) {
+		t.Fatalf("got %v, want the mapped error", err)
+	}
+
+	err := try.CallWith(func() { try.Check(errTest) }, func(error) error { panic("transform") })
+	if !errors.Is(err, errTest) || !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %v, want the original error joined with the panic of transform", err)
+	}
+}
+
+func TestGoWithErr(t *testing.T) {
+	before := runtime.NumGoroutine()
+	for _, tt := range []struct {
+		fn   func()
+		want error
+	}{
+		{func() {}, nil},
+		{func() { try.Check(errTest) }, errTest},
+	} {
+		ch := try.GoWithErr(tt.fn)
+		if err := <-ch; !errors.Is(err, tt.want) || (tt.want == nil) != (err == nil) {
+			t.Fatalf("got %v, want %v", err, tt.want)
+		}
+		if _, ok := <-ch; ok {
+			t.Fatal("channel not closed after the result")
+		}
+	}
+	// The channel is buffered, so the goroutine completes even if nobody reads the result.
+	try.GoWithErr(func() {})
+	time.Sleep(10 * time.Millisecond)
+	if n := runtime.NumGoroutine(); n > before {
+		t.Fatalf("%d goroutines leaked", n-before)
+	}
+}
+
+func TestMust(t *testing.T) {
+	trytest.NoPanic(t, func() {
+		if v := try.Must(1, nil); v != 1 {
+			t.Fatalf("Must: got %d", v)
+		}
+		if a, b := try.Must2(1, "2", nil); a != 1 || b != "2" {
+			t.Fatalf("Must2: got %v %v", a, b)
+		}
+		if a, b, c := try.Must3(1, "2", 3.0, nil); a != 1 || b != "2" || c != 3.0 {
+			t.Fatalf("Must3: got %v %v %v", a, b, c)
+		}
+	})
+	for _, fn := range []func(){
+		func() { try.Must(1, errTest) },
+		func() { try.Must2(1, 2, errTest) },
+		func() { try.Must3(1, 2, 3, errTest) },
+	} {
+		err := try.Call(fn)
+		if !errors.Is(err, errTest) {
+			t.Fatalf("got %v, want %v", err, errTest)
+		}
+		if file, _, _ := try.Location(err); !strings.HasSuffix(file, "try_test.go") {
+			t.Fatalf("got location in %s, want the caller of Must", file)
+		}
+	}
+}
+
+func TestTry(t *testing.T) {
+	if err := try.Try(func() {}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := try.Try(func() { try.Check(errTest) }); !errors.Is(err, errTest) {
+		t.Fatalf("got %v, want %v", err, errTest)
+	}
+
+	v, err := try.Try1(func() int { return 42 })
+	if v != 42 || err != nil {
+		t.Fatalf("got %d, %v, want 42, nil", v, err)
+	}
+	v, err = try.Try1(func() int { return try.Val(0, errTest) + 1 })
+	if v != 0 || !errors.Is(err, errTest) {
+		t.Fatalf("got %d, %v, want 0, %v", v, err, errTest)
+	}
+}
+
+func TestHandleIf(t *testing.T) {
+	isTest := func(err error) bool { return errors.Is(err, errTest) }
+	var got error
+	func() {
+		defer try.HandleIf(isTest, func(err error) { got = err })
+		try.Check(errTest)
+	}()
+	if !errors.Is(got, errTest) {
+		t.Fatalf("got %v, want %v handled", got, errTest)
+	}
+
+	errOther := errors.New("other")
+	r := func() (r any) {
+		defer func() { r = recover() }()
+		defer try.HandleIf(isTest, func(err error) { t.Fatalf("handler called with %v", err) })
+		panic(errOther)
+	}()
+	if r != errOther {
+		t.Fatalf("got %v, want the original error re-raised", r)
+	}
+}
+
+func TestCatchRethrow(t *testing.T) {
+	type state struct{ n int }
+	var got error
+	r := func() (r any) {
+		defer func() { r = recover() }()
+		defer try.CatchRethrow(func(err error) { got = err })
+		panic(state{7})
+	}()
+	if r != (state{7}) {
+		t.Fatalf("got %#v, want the original panic value re-raised", r)
+	}
+	if !errors.Is(got, try.ErrPanic) {
+		t.Fatalf("got %v, want the converted panic-error", got)
+	}
+}
+
+func TestMuteIf(t *testing.T) {
+	errClosed := errors.New("already closed")
+	isClosed := func(err error) bool { return errors.Is(err, errClosed) }
+
+	r := func() (r any) {
+		defer func() { r = recover() }()
+		defer try.MuteIf(isClosed)
+		panic(errClosed)
+	}()
+	if r != nil {
+		t.Fatalf("got %v, want the panic muted", r)
+	}
+
+	r = func() (r any) {
+		defer func() { r = recover() }()
+		defer try.MuteIf(isClosed)
+		panic("boom")
+	}()
+	if r != "boom" {
+		t.Fatalf("got %#v, want the original panic value re-raised", r)
+	}
+}
+
+func TestCallResult(t *testing.T) {
+	if err := try.CallResult(func() error { return nil }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := try.CallResult(func() error { return errTest })
+	if err != errTest || try.IsPanic(err) {
+		t.Fatalf("got %v, want the returned %v", err, errTest)
+	}
+	err = try.CallResult(func() error { panic("boom") })
+	if !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %v, want the panic-error", err)
+	}
+
+	// A panic with an error value is told apart from the same error returned.
+	err = try.CallResult(func() error { panic(io.EOF) })
+	if err == io.EOF || !errors.Is(err, io.EOF) || !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %#v, want io.EOF marked as a panic", err)
+	}
+	err = try.CallResult(func() error { try.Check(io.EOF); return nil })
+	if !errors.Is(err, io.EOF) || !errors.Is(err, try.ErrTry) || errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %v, want io.EOF raised by a try helper", err)
+	}
+
+	// A function returning an error and then panicking in a deferred call reports the panic only.
+	err = try.CallResult(func() (err error) {
+		defer func() { panic("boom") }()
+		return errTest
+	})
+	if !errors.Is(err, try.ErrPanic) || errors.Is(err, errTest) {
+		t.Fatalf("got %v, want the panic-error without the returned error", err)
+	}
+}
+
+func TestValOrZero(t *testing.T) {
+	partial := func() ([]int, error) { return []int{1, 2}, errTest }
+	if got := try.ValOrZero(partial()); got != nil {
+		t.Fatalf("got %v, want the zero value on error", got)
+	}
+	if got := try.ValOrZero(42, nil); got != 42 {
+		t.Fatalf("got %d, want 42", got)
+	}
+}
+
+func TestValCtx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var got int
+	if err := try.Call(func() { got = try.ValCtx(ctx, 42, nil) }); err != nil || got != 42 {
+		t.Fatalf("got %d, %v, want 42, nil", got, err)
+	}
+	if err := try.Call(func() { try.ValCtx(ctx, 42, errTest) }); !errors.Is(err, errTest) {
+		t.Fatalf("got %v, want %v", err, errTest)
+	}
+	cancel()
+	if err := try.Call(func() { try.ValCtx(ctx, 42, errTest) }); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want the context error first", err)
+	}
+}
+
+func TestSafe(t *testing.T) {
+	calls := 0
+	fn := try.Safe(func() {
+		calls++
+		try.Check(errTest)
+	})
+	if calls != 0 {
+		t.Fatal("fn called before the wrapper")
+	}
+	if err := fn(); !errors.Is(err, errTest) || calls != 1 {
+		t.Fatalf("got %v after %d calls, want %v after 1", err, calls, errTest)
+	}
+	if err := try.Safe(func() {})(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	v, err := try.Safe1(func() int { return 42 })()
+	if v != 42 || err != nil {
+		t.Fatalf("got %d, %v, want 42, nil", v, err)
+	}
+	v, err = try.Safe1(func() int { panic("boom") })()
+	if v != 0 || !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %d, %v, want 0 and the panic-error", v, err)
+	}
+}
+
+func TestWrapPanic(t *testing.T) {
+	if err := try.WrapPanic("processing batch", func() {}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := try.WrapPanic("processing batch", func() { try.Check(errTest) })
+	if !errors.Is(err, errTest) || !strings.HasPrefix(err.Error(), "processing batch: test error") {
+		t.Fatalf("got %v, want %v wrapped with the message", err, errTest)
+	}
+}
+
+func TestValDeref(t *testing.T) {
+	v := 42
+	var got int
+	if err := try.Call(func() { got = try.ValDeref(&v, nil) }); err != nil || got != 42 {
+		t.Fatalf("got %d, %v, want 42, nil", got, err)
+	}
+	if err := try.Call(func() { try.ValDeref[int](nil, nil) }); !errors.Is(err, try.ErrNilPointer) {
+		t.Fatalf("got %v, want %v", err, try.ErrNilPointer)
+	}
+	if err := try.Call(func() { try.ValDeref[int](nil, errTest) }); !errors.Is(err, errTest) {
+		t.Fatalf("got %v, want %v", err, errTest)
+	}
+}
+
+func TestAsError(t *testing.T) {
+	if err := try.AsError(nil, "handler"); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	err := try.AsError(errTest, "handler")
+	if !errors.Is(err, errTest) || err.Error() != "handler: test error" {
+		t.Fatalf("got %v, want %v with the label", err, errTest)
+	}
+	err = try.AsError(42, "handler")
+	if !errors.Is(err, try.ErrPanic) || err.Error() != "handler: 42" {
+		t.Fatalf("got %v, want the formatted value matching ErrPanic", err)
+	}
+	if err := try.AsError(errTest, ""); err != errTest {
+		t.Fatalf("got %v, want %v without a label", err, errTest)
+	}
+}
+
+func TestCatchInto(t *testing.T) {
+	var errs []error
+	func() {
+		defer try.CatchInto(&errs)
+		defer func() {
+			defer try.CatchInto(&errs)
+			panic("first")
+		}()
+		try.Check(errTest)
+	}()
+	if len(errs) != 2 || errs[0].Error() != "first" || !errors.Is(errs[1], errTest) {
+		t.Fatalf("got %v, want both errors in order", errs)
+	}
+
+	func() {
+		defer try.CatchInto(&errs)
+	}()
+	if len(errs) != 2 {
+		t.Fatalf("got %v, want nothing appended without a panic", errs)
+	}
+}
+
+func TestHandleLoc(t *testing.T) {
+	var (
+		gotErr  error
+		gotFile string
+		gotLine int
+	)
+	handle := func(err error, file string, line int) { gotErr, gotFile, gotLine = err, file, line }
+
+	_, _, line, _ := runtime.Caller(0)
+	func() {
+		defer try.HandleLoc(handle)
+		try.Check(errTest) // line+3
+	}()
+	if !errors.Is(gotErr, errTest) || !strings.HasSuffix(gotFile, "try_test.go") || gotLine != line+3 {
+		t.Fatalf("got %v at %s:%d, want %v at try_test.go:%d", gotErr, gotFile, gotLine, errTest, line+3)
+	}
+
+	func() {
+		defer try.HandleLoc(handle)
+		panic("boom")
+	}()
+	if !errors.Is(gotErr, try.ErrPanic) || gotFile != "" || gotLine != 0 {
+		t.Fatalf("got %v at %s:%d, want the panic-error without a location", gotErr, gotFile, gotLine)
+	}
+}
+
+func TestRecoverAs(t *testing.T) {
+	type stop struct{ reason string }
+	var (
+		got stop
+		ok  bool
+	)
+	func() {
+		defer func() { got, ok = try.RecoverAs[stop](recover()) }()
+		panic(stop{"done"})
+	}()
+	if !ok || got.reason != "done" {
+		t.Fatalf("got %v, %t, want the typed value", got, ok)
+	}
+
+	func() {
+		defer func() { got, ok = try.RecoverAs[stop](recover()) }()
+	}()
+	if ok {
+		t.Fatal("got true without a panic")
+	}
+
+	r := func() (r any) {
+		defer func() { r = recover() }()
+		defer func() { try.RecoverAs[stop](recover()) }()
+		panic("boom")
+	}()
+	if r != "boom" {
+		t.Fatalf("got %#v, want the other value re-raised", r)
+	}
+}
+
+func TestCatchConvert(t *testing.T) {
+	errNotFound := errors.New("not found")
+	convert := func(r any) error {
+		switch r {
+		case "not found":
+			return errNotFound
+		case "ignore":
+			return nil
+		}
+		return fmt.Errorf("converted: %v", r)
+	}
+	run := func(v any) (err error) {
+		defer try.CatchConvert(&err, convert)
+		panic(v)
+	}
+
+	if err := run("not found"); err != errNotFound {
+		t.Fatalf("got %v, want %v", err, errNotFound)
+	}
+	if err := run(42); err == nil || err.Error() != "converted: 42" {
+		t.Fatalf("got %v, want the converted value", err)
+	}
+	if err := run("ignore"); err != nil {
+		t.Fatalf("got %v, want the panic swallowed", err)
+	}
+}
+
+func TestCatchReset(t *testing.T) {
+	resets := 0
+	reset := func() { resets++ }
+	run := func(fn func()) (err error) {
+		defer try.CatchReset(&err, reset)
+		fn()
+		return nil
+	}
+
+	if err := run(func() {}); err != nil || resets != 1 {
+		t.Fatalf("got %v after %d resets, want nil after 1", err, resets)
+	}
+	if err := run(func() { try.Check(errTest) }); !errors.Is(err, errTest) || resets != 2 {
+		t.Fatalf("got %v after %d resets, want %v after 2", err, resets, errTest)
+	}
+}
+
+func TestValMap(t *testing.T) {
+	var got map[string]int
+	if err := try.Call(func() { got = try.ValMap[string, int](nil, nil) }); err != nil || got == nil || len(got) != 0 {
+		t.Fatalf("got %#v, %v, want an empty non-nil map", got, err)
+	}
+	m := map[string]int{"a": 1}
+	if got := try.ValMap(m, nil); got["a"] != 1 {
+		t.Fatalf("got %v, want %v", got, m)
+	}
+	if err := try.Call(func() { try.ValMap(m, errTest) }); !errors.Is(err, errTest) {
+		t.Fatalf("got %v, want %v", err, errTest)
+	}
+}
+
+func TestMuster(t *testing.T) {
+	calls := 0
+	newThing := try.Muster(func() (int, error) {
+		calls++
+		return 42, nil
+	})
+	if calls != 0 {
+		t.Fatal("fn called before the wrapper")
+	}
+	if v := newThing(); v != 42 || calls != 1 {
+		t.Fatalf("got %d after %d calls, want 42 after 1", v, calls)
+	}
+	err := try.Call(func() { try.Muster(func() (int, error) { return 0, errTest })() })
+	if !errors.Is(err, errTest) {
+		t.Fatalf("got %v, want %v", err, errTest)
+	}
+}
+
+func TestRequireEqual(t *testing.T) {
+	trytest.NoPanic(t, func() {
+		try.RequireEqual(2, 2)
+		try.RequireNoErr(nil)
+	})
+	err := try.Call(func() { try.RequireEqual("a", "b") })
+	if err == nil || !strings.HasPrefix(err.Error(), "got a, want b") {
+		t.Fatalf("got %v, want the diagnostic message", err)
+	}
+	trytest.PanicsWith(t, errTest, func() { try.RequireNoErr(errTest) })
+}
+
+func TestOKAll(t *testing.T) {
+	trytest.NoPanic(t, func() { try.OKAll(nil, nil) })
+	errOther := errors.New("other")
+	err := try.Call(func() { try.OKAll(nil, errTest, errOther) })
+	if !errors.Is(err, errTest) || !errors.Is(err, errOther) {
+		t.Fatalf("got %v, want both errors joined", err)
+	}
+}
+
+func TestCheckHint(t *testing.T) {
+	trytest.NoPanic(t, func() { try.CheckHint(nil, "unused") })
+	err := try.Call(func() { try.CheckHint(errTest, "check your API token") })
+	if !errors.Is(err, errTest) || try.Hint(err) != "check your API token" {
+		t.Fatalf("got %v with hint %q, want %v with the hint", err, try.Hint(err), errTest)
+	}
+	if try.Hint(errTest) != "" {
+		t.Fatal("hint for an error without one")
+	}
+}
+
+func TestRecoverer(t *testing.T) {
+	var got []error
+	recoverer := try.Recoverer(func(err error) { got = append(got, err) })
+
+	ran := false
+	recoverer(func() { ran = true })()
+	if !ran || len(got) != 0 {
+		t.Fatalf("ran %t, got %v, want the function run without errors", ran, got)
+	}
+	handler := recoverer(func() { try.Check(errTest) })
+	handler()
+	handler()
+	if len(got) != 2 || !errors.Is(got[0], errTest) {
+		t.Fatalf("got %v, want the error passed to onPanic for each call", got)
+	}
+}
+
+func TestValBytes(t *testing.T) {
+	if b := try.ValBytes(nil, nil); b == nil || len(b) != 0 {
+		t.Fatalf("got %#v, want an empty non-nil slice", b)
+	}
+	if b := try.ValBytes([]byte("ok"), nil); string(b) != "ok" {
+		t.Fatalf("got %q, want %q", b, "ok")
+	}
+	if s := try.ValString("ok", nil); s != "ok" {
+		t.Fatalf("got %q, want %q", s, "ok")
+	}
+	trytest.PanicsWith(t, errTest, func() { try.ValBytes(nil, errTest) })
+	trytest.PanicsWith(t, errTest, func() { try.ValString("", errTest) })
+}
+
+func TestInvoke(t *testing.T) {
+	if err := try.Invoke("plugin", func() error { return nil }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := try.Invoke("plugin", func() error { return errTest })
+	if !errors.Is(err, errTest) || err.Error() != `"plugin" failed: test error` {
+		t.Fatalf("got %v, want %v attributed to the callback", err, errTest)
+	}
+	err = try.Invoke("plugin", func() error { panic("boom") })
+	if !errors.Is(err, try.ErrPanic) || err.Error() != `"plugin" failed: boom` {
+		t.Fatalf("got %v, want the panic attributed to the callback", err)
+	}
+}
+
+func TestValNamed(t *testing.T) {
+	if v := try.ValNamed("db.Query", 42, nil); v != 42 {
+		t.Fatalf("got %d, want 42", v)
+	}
+	err := try.Call(func() { try.ValNamed("db.Query", 0, errTest) })
+	if !errors.Is(err, errTest) || !strings.HasPrefix(err.Error(), "db.Query: test error") {
+		t.Fatalf("got %v, want %v labeled with the operation", err, errTest)
+	}
+}
+
+func TestDecorate(t *testing.T) {
+	if v := try.Decorate(func() (int, error) { return 1, nil })(); v != 1 {
+		t.Fatalf("got %d, want 1", v)
+	}
+	if v1, v2 := try.Decorate2(func() (int, string, error) { return 1, "a", nil })(); v1 != 1 || v2 != "a" {
+		t.Fatalf("got %d, %q, want 1, a", v1, v2)
+	}
+	if v1, v2, v3 := try.Decorate3(func() (int, string, bool, error) { return 1, "a", true, nil })(); v1 != 1 || v2 != "a" || !v3 {
+		t.Fatalf("got %d, %q, %t, want 1, a, true", v1, v2, v3)
+	}
+
+	trytest.PanicsWith(t, errTest, func() { try.Decorate(func() (int, error) { return 0, errTest })() })
+	trytest.PanicsWith(t, errTest, func() { try.Decorate2(func() (int, int, error) { return 0, 0, errTest })() })
+	trytest.PanicsWith(t, errTest, func() { try.Decorate3(func() (int, int, int, error) { return 0, 0, 0, errTest })() })
+}
+
+func TestProtect(t *testing.T) {
+	if err := try.Protect0(func() error { panic("boom") })(); !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %v, want the panic-error", err)
+	}
+	if err := try.Protect0(func() error { return errTest })(); err != errTest {
+		t.Fatalf("got %v, want %v", err, errTest)
+	}
+
+	parse := try.Protect1(func(s string) (int, error) { return try.Val(strconv.Atoi(s)), nil })
+	if v, err := parse("42"); v != 42 || err != nil {
+		t.Fatalf("got %d, %v, want 42, nil", v, err)
+	}
+	if v, err := parse("x"); v != 0 || !errors.Is(err, strconv.ErrSyntax) {
+		t.Fatalf("got %d, %v, want 0 and the parse error", v, err)
+	}
+
+	div := try.Protect2(func(a, b int) (int, error) { return a / b, nil })
+	if v, err := div(6, 3); v != 2 || err != nil {
+		t.Fatalf("got %d, %v, want 2, nil", v, err)
+	}
+	if _, err := div(1, 0); err == nil || !strings.Contains(err.Error(), "divide by zero") {
+		t.Fatalf("got %v, want the runtime error", err)
+	}
+}
+
+func TestSetStrictSafe(t *testing.T) {
+	if v := try.SafeVal(1, errTest); v != 1 {
+		t.Fatalf("got %d, want the value with the error ignored", v)
+	}
+
+	try.SetStrictSafe(true)
+	t.Cleanup(func() { try.SetStrictSafe(false) })
+	if v := try.SafeVal(1, nil); v != 1 {
+		t.Fatalf("got %d, want 1", v)
+	}
+	for name, fn := range map[string]func(){
+		"SafeVal":  func() { try.SafeVal(1, errTest) },
+		"SafeVal2": func() { try.SafeVal2(1, 2, errTest) },
+		"SafeVal3": func() { try.SafeVal3(1, 2, 3, errTest) },
+		"SafeVal4": func() { try.SafeVal4(1, 2, 3, 4, errTest) },
+	} {
+		err := try.Call(fn)
+		if !errors.Is(err, errTest) || !strings.HasPrefix(err.Error(), "try: ignored error: test error") {
+			t.Errorf("%s: got %v, want the ignored error raised", name, err)
+		}
+	}
+}
+
+func TestTeeVal(t *testing.T) {
+	var tapped []int
+	tap := func(v int) { tapped = append(tapped, v) }
+	if v := try.TeeVal(42, nil, tap); v != 42 || !slices.Equal(tapped, []int{42}) {
+		t.Fatalf("got %d, tapped %v, want 42 tapped once", v, tapped)
+	}
+	trytest.PanicsWith(t, errTest, func() { try.TeeVal(1, errTest, tap) })
+	if len(tapped) != 1 {
+		t.Fatalf("tapped %v, want tap not called on error", tapped)
+	}
+}
+
+func TestCatchFresh(t *testing.T) {
+	var err error
+	var got []string
+	for i := range 4 {
+		func() {
+			defer try.CatchFresh(&err)
+			if i%2 == 1 {
+				try.Check(fmt.Errorf("iteration %d", i))
+			}
+		}()
+		if i%2 == 1 {
+			got = append(got, err.Error())
+		}
+	}
+	if len(got) != 2 || !strings.HasPrefix(got[0], "iteration 1\n") || !strings.HasPrefix(got[1], "iteration 3\n") {
+		t.Fatalf("got %q, want each iteration's own error", got)
+	}
+	if n := len(try.Errors(err)); n != 1 {
+		t.Fatalf("got %d errors, want only the last one", n)
+	}
+
+	// Catch joins into the shared error instead.
+	err = nil
+	for i := range 2 {
+		func() {
+			defer try.Catch(&err)
+			try.Check(fmt.Errorf("iteration %d", i))
+		}()
+	}
+	if n := len(try.Errors(err)); n != 2 {
+		t.Fatalf("got %d errors, want both joined by Catch", n)
+	}
+}
@@ -0,0 +1,11 @@
+package trytest_test
+
+import (
+	"testing"
+
+	"github.com/goldic/try/trytest"
+)
+
+func BenchmarkTry(b *testing.B) {
+	trytest.Bench(b)
+}
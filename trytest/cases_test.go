@@ -0,0 +1,74 @@
+package trytest
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+// fakeT records the subtests run by runCases and their failures.
+// Fatalf stops the calling goroutine like testing.T does.
+type fakeT struct {
+	testing.TB
+	name   string
+	failed []string // names of the failed subtests with their messages
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Fatalf(format string, args ...any) {
+	f.failed = append(f.failed, f.name+": "+fmt.Sprintf(format, args...))
+	runtime.Goexit()
+}
+
+func (f *fakeT) Run(name string, fn func(t *fakeT)) bool {
+	sub := &fakeT{TB: f.TB, name: name}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(sub)
+	}()
+	<-done
+	f.failed = append(f.failed, sub.failed...)
+	return len(sub.failed) == 0
+}
+
+func TestRunCases(t *testing.T) {
+	cases := []Case[int]{
+		{Name: "positive", In: 1},
+		{In: -1, Panics: true},
+	}
+
+	var ran []int
+	f := &fakeT{TB: t}
+	runCases(f, cases, func(in int) {
+		ran = append(ran, in)
+		try.Require(in > 0, "not positive")
+	})
+	if len(f.failed) != 0 || !slices.Equal(ran, []int{1, -1}) {
+		t.Fatalf("failed %q after cases %v, want both cases passed", f.failed, ran)
+	}
+
+	f = &fakeT{TB: t}
+	runCases(f, cases, func(int) { panic("boom") })
+	if want := []string{"positive: unexpected panic: boom"}; !slices.Equal(f.failed, want) {
+		t.Fatalf("failed %q, want %q", f.failed, want)
+	}
+
+	f = &fakeT{TB: t}
+	runCases(f, cases, func(int) {})
+	if want := []string{"case 1: expected panic for -1"}; !slices.Equal(f.failed, want) {
+		t.Fatalf("failed %q, want %q", f.failed, want)
+	}
+}
+
+func TestCases(t *testing.T) {
+	var ran []int
+	Cases(t, []Case[int]{{In: 1}, {In: 2}}, func(in int) { ran = append(ran, in) })
+	if !slices.Equal(ran, []int{1, 2}) {
+		t.Fatalf("ran cases %v, want [1 2]", ran)
+	}
+}
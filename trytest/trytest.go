@@ -0,0 +1,69 @@
+// Package trytest provides test helpers for code that uses the try package.
+package trytest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+// NoPanic runs fn and fails the test if it panics, reporting the recovered error and its location.
+func NoPanic(t testing.TB, fn func()) {
+	t.Helper()
+	if err := try.Call(fn); err != nil {
+		t.Fatalf("unexpected panic: %v", err)
+	}
+}
+
+// PanicsWith runs fn and fails the test unless it panics with an error matching want (see errors.Is).
+func PanicsWith(t testing.TB, want error, fn func()) {
+	t.Helper()
+	err := try.Call(fn)
+	if err == nil {
+		t.Fatalf("expected panic with %v", want)
+	}
+	if !errors.Is(err, want) {
+		t.Fatalf("unexpected panic: %v, want %v", err, want)
+	}
+}
+
+// Case is a test case of Cases.
+type Case[T any] struct {
+	Name   string // subtest name, the case index by default
+	In     T      // input passed to the tested function
+	Panics bool   // whether the tested function is expected to panic
+}
+
+// Cases runs fn with the input of each case as a subtest and checks whether it panics as expected.
+func Cases[T any](t *testing.T, cases []Case[T], fn func(in T)) {
+	t.Helper()
+	runCases(t, cases, fn)
+}
+
+// runner is a test which runs subtests of its own type, like *testing.T.
+type runner[R any] interface {
+	testing.TB
+	Run(name string, fn func(t R)) bool
+}
+
+func runCases[T any, R runner[R]](t R, cases []Case[T], fn func(in T)) {
+	t.Helper()
+	for i, c := range cases {
+		name := c.Name
+		if name == "" {
+			name = fmt.Sprintf("case %d", i)
+		}
+		t.Run(name, func(t R) {
+			t.Helper()
+			err := try.Call(func() { fn(c.In) })
+			switch {
+			case err != nil && !c.Panics:
+				t.Fatalf("unexpected panic: %v", err)
+			case err == nil && c.Panics:
+				t.Fatalf("expected panic for %v", c.In)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package trytest_test
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/goldic/try"
+	"github.com/goldic/try/trytest"
+)
+
+var errTest = errors.New("test error")
+
+// fakeTB records the failure of a test helper. Fatalf stops the calling goroutine like testing.T does.
+type fakeTB struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Fatalf(format string, args ...any) {
+	f.failed = true
+	f.msg = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+// check runs the helper call fn with a fakeTB in its own goroutine, so that Fatalf can stop it.
+func check(t *testing.T, fn func(tb testing.TB)) *fakeTB {
+	f := &fakeTB{TB: t}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(f)
+	}()
+	<-done
+	return f
+}
+
+func TestNoPanic(t *testing.T) {
+	if f := check(t, func(tb testing.TB) { trytest.NoPanic(tb, func() {}) }); f.failed {
+		t.Fatalf("failed without a panic: %s", f.msg)
+	}
+	f := check(t, func(tb testing.TB) { trytest.NoPanic(tb, func() { try.Check(errTest) }) })
+	if !f.failed || !strings.Contains(f.msg, "unexpected panic: test error") || !strings.Contains(f.msg, "trytest_test.go:") {
+		t.Fatalf("got %t, %q, want a failure with the error and its location", f.failed, f.msg)
+	}
+}
+
+func TestPanicsWith(t *testing.T) {
+	if f := check(t, func(tb testing.TB) { trytest.PanicsWith(tb, errTest, func() { try.Check(errTest) }) }); f.failed {
+		t.Fatalf("failed with the expected panic: %s", f.msg)
+	}
+	if f := check(t, func(tb testing.TB) { trytest.PanicsWith(tb, errTest, func() {}) }); !f.failed {
+		t.Fatal("passed without a panic")
+	}
+	f := check(t, func(tb testing.TB) { trytest.PanicsWith(tb, errTest, func() { panic("boom") }) })
+	if !f.failed || !strings.Contains(f.msg, "unexpected panic: boom") {
+		t.Fatalf("got %t, %q, want a failure for the other panic", f.failed, f.msg)
+	}
+}
@@ -0,0 +1,26 @@
+package try
+
+// Tuple2 is a pair of values.
+type Tuple2[T1, T2 any] struct {
+	V1 T1
+	V2 T2
+}
+
+// Tuple3 is a triple of values.
+type Tuple3[T1, T2, T3 any] struct {
+	V1 T1
+	V2 T2
+	V3 T3
+}
+
+// ValTuple returns v1, v2 as a Tuple2 or panics when err is not null.
+func ValTuple[T1, T2 any](v1 T1, v2 T2, err error) Tuple2[T1, T2] {
+	checkErr(err)
+	return Tuple2[T1, T2]{V1: v1, V2: v2}
+}
+
+// ValTuple3 returns v1, v2, v3 as a Tuple3 or panics when err is not null.
+func ValTuple3[T1, T2, T3 any](v1 T1, v2 T2, v3 T3, err error) Tuple3[T1, T2, T3] {
+	checkErr(err)
+	return Tuple3[T1, T2, T3]{V1: v1, V2: v2, V3: v3}
+}
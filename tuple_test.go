@@ -0,0 +1,19 @@
+package try_test
+
+import (
+	"testing"
+
+	"github.com/goldic/try"
+	"github.com/goldic/try/trytest"
+)
+
+func TestValTuple(t *testing.T) {
+	if p := try.ValTuple(1, "a", nil); p.V1 != 1 || p.V2 != "a" {
+		t.Fatalf("got %+v, want {1 a}", p)
+	}
+	if p := try.ValTuple3(1, "a", true, nil); p.V1 != 1 || p.V2 != "a" || !p.V3 {
+		t.Fatalf("got %+v, want {1 a true}", p)
+	}
+	trytest.PanicsWith(t, errTest, func() { try.ValTuple(1, "a", errTest) })
+	trytest.PanicsWith(t, errTest, func() { try.ValTuple3(1, "a", true, errTest) })
+}
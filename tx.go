@@ -0,0 +1,25 @@
+package try
+
+// Tx is a transaction, e.g. *sql.Tx.
+type Tx interface {
+	Commit() error
+	Rollback() error
+}
+
+// TxBeginner begins transactions of type T, e.g. *sql.DB.
+type TxBeginner[T Tx] interface {
+	Begin() (T, error)
+}
+
+// Transact begins a transaction and runs fn safely. The transaction is committed if fn succeeds,
+// otherwise it is rolled back and the error or recovered panic of fn is returned, joined with the rollback error.
+func Transact[T Tx](db TxBeginner[T], fn func(tx T) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := CallResult(func() error { return fn(tx) }); err != nil {
+		return Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,48 @@
+package try_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/goldic/try"
+)
+
+// fakeTx records how the transaction ended.
+type fakeTx struct {
+	committed, rolledBack bool
+	rollbackErr           error
+}
+
+func (tx *fakeTx) Commit() error   { tx.committed = true; return nil }
+func (tx *fakeTx) Rollback() error { tx.rolledBack = true; return tx.rollbackErr }
+
+type fakeDB struct {
+	tx  *fakeTx
+	err error
+}
+
+func (db *fakeDB) Begin() (*fakeTx, error) { return db.tx, db.err }
+
+func TestTransact(t *testing.T) {
+	db := &fakeDB{tx: &fakeTx{}}
+	if err := try.Transact(db, func(tx *fakeTx) error { return nil }); err != nil || !db.tx.committed || db.tx.rolledBack {
+		t.Fatalf("got %v, %+v, want committed", err, db.tx)
+	}
+
+	db = &fakeDB{tx: &fakeTx{}}
+	if err := try.Transact(db, func(tx *fakeTx) error { return errTest }); err != errTest || db.tx.committed || !db.tx.rolledBack {
+		t.Fatalf("got %v, %+v, want rolled back", err, db.tx)
+	}
+
+	errRollback := errors.New("rollback failed")
+	db = &fakeDB{tx: &fakeTx{rollbackErr: errRollback}}
+	err := try.Transact(db, func(tx *fakeTx) error { panic("boom") })
+	if !errors.Is(err, try.ErrPanic) || !errors.Is(err, errRollback) || !db.tx.rolledBack {
+		t.Fatalf("got %v, %+v, want the panic and the rollback error", err, db.tx)
+	}
+
+	db = &fakeDB{err: errTest}
+	if err := try.Transact(db, func(tx *fakeTx) error { t.Fatal("fn called"); return nil }); err != errTest {
+		t.Fatalf("got %v, want the begin error", err)
+	}
+}
@@ -0,0 +1,47 @@
+package try
+
+import "errors"
+
+// Validator accumulates the failures of several checks. A zero Validator is ready to use.
+type Validator struct {
+	errs []error
+}
+
+// Check adds msg to the failures if cond is false.
+func (v *Validator) Check(cond bool, msg string) {
+	if !cond {
+		v.errs = append(v.errs, errors.New(msg))
+	}
+}
+
+// Err returns the joined error of all failures or nil.
+func (v *Validator) Err() error {
+	return joinSlice(v.errs)
+}
+
+// Condition is a precondition checked by Guard.
+type Condition struct {
+	OK  bool
+	Msg string
+}
+
+// Guard panics with the message of the first condition that is not OK.
+func Guard(conditions ...Condition) {
+	for _, c := range conditions {
+		if !c.OK {
+			checkErr(errors.New(c.Msg))
+		}
+	}
+}
+
+// RequireAll runs all checks and panics with the joined errors and recovered panics of the failed ones.
+// Unlike Chain, the remaining checks still run after a failure.
+func RequireAll(checks ...func() error) {
+	var errs []error
+	for _, check := range checks {
+		if err := CallResult(check); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	checkErr(joinSlice(errs))
+}
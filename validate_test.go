@@ -0,0 +1,66 @@
+package try_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/goldic/try"
+	"github.com/goldic/try/trytest"
+)
+
+func TestValidator(t *testing.T) {
+	var v try.Validator
+	v.Check(true, "ok")
+	if err := v.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	v.Check(false, "name is empty")
+	v.Check(true, "ok")
+	v.Check(false, "age is negative")
+	err := v.Err()
+	if err == nil || err.Error() != "name is empty\nage is negative" {
+		t.Fatalf("got %v, want both failures", err)
+	}
+	if n := len(try.Errors(err)); n != 2 {
+		t.Fatalf("got %d errors, want 2", n)
+	}
+}
+
+func TestGuard(t *testing.T) {
+	x, name := 1, ""
+	trytest.NoPanic(t, func() {
+		try.Guard(try.Condition{OK: x > 0, Msg: "x must be positive"})
+	})
+	err := try.Call(func() {
+		try.Guard(
+			try.Condition{OK: x > 0, Msg: "x must be positive"},
+			try.Condition{OK: name != "", Msg: "name is required"},
+			try.Condition{OK: x > 10, Msg: "x is too small"},
+		)
+	})
+	if err == nil || !strings.HasPrefix(err.Error(), "name is required") {
+		t.Fatalf("got %v, want the first failed condition", err)
+	}
+}
+
+func TestRequireAll(t *testing.T) {
+	ran := 0
+	check := func(err error) func() error {
+		return func() error { ran++; return err }
+	}
+	trytest.NoPanic(t, func() { try.RequireAll(check(nil), check(nil)) })
+
+	ran = 0
+	errOther := errors.New("other")
+	err := try.Call(func() {
+		try.RequireAll(check(errTest), func() error { ran++; panic("boom") }, check(nil), check(errOther))
+	})
+	if ran != 4 {
+		t.Fatalf("ran %d checks, want all 4", ran)
+	}
+	if !errors.Is(err, errTest) || !errors.Is(err, errOther) || !errors.Is(err, try.ErrPanic) {
+		t.Fatalf("got %v, want all failures joined", err)
+	}
+}